@@ -0,0 +1,433 @@
+package sacco
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// multisigProtoTypeURL is the type URL of k-of-n threshold multisig public keys packed
+// into a google.protobuf.Any.
+const multisigProtoTypeURL = "/cosmos.crypto.multisig.LegacyAminoPubKey"
+
+// Account is the on-chain state of an account, as returned by the LCD.
+type Account struct {
+	// Type is the type of the account, like "cosmos-sdk/Account" or
+	// "cosmos-sdk/ContinuousVestingAccount" on amino chains, and
+	// "/cosmos.auth.v1beta1.BaseAccount" on Cosmos SDK v0.40+ chains.
+	Type string
+
+	Address       string
+	Coins         Coins
+	AccountNumber int64
+	Sequence      int64
+
+	// PubKey is the public key of the account, nil if the account never signed
+	// a transaction.
+	PubKey *SigPubKey
+
+	// Vesting holds the vesting schedule of the account, nil if the account isn't a
+	// vesting account.
+	Vesting *VestingInfo
+}
+
+// VestingInfo is the vesting schedule of a ContinuousVestingAccount, DelayedVestingAccount
+// or PeriodicVestingAccount.
+type VestingInfo struct {
+	OriginalVesting  Coins
+	DelegatedFree    Coins
+	DelegatedVesting Coins
+
+	// StartTime is the UNIX time vesting starts at, zero for DelayedVestingAccount.
+	StartTime int64
+
+	// EndTime is the UNIX time every original vesting coin is vested at.
+	EndTime int64
+}
+
+// jsonInt64 is an int64 which decodes from both JSON numbers and strings, since amino
+// JSON and protobuf JSON encode 64 bits integers as strings.
+type jsonInt64 int64
+
+// UnmarshalJSON implements the json.Unmarshaler interface for jsonInt64.
+func (i *jsonInt64) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	v, err := strconv.ParseInt(string(bytes.Trim(data, `"`)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+
+	*i = jsonInt64(v)
+
+	return nil
+}
+
+// accountValue holds the fields of every supported account type, flattened.
+type accountValue struct {
+	Address          string          `json:"address"`
+	Coins            Coins           `json:"coins"`
+	PublicKey        json.RawMessage `json:"public_key"`
+	PubKey           json.RawMessage `json:"pub_key"`
+	AccountNumber    jsonInt64       `json:"account_number"`
+	Sequence         jsonInt64       `json:"sequence"`
+	OriginalVesting  Coins           `json:"original_vesting"`
+	DelegatedFree    Coins           `json:"delegated_free"`
+	DelegatedVesting Coins           `json:"delegated_vesting"`
+	StartTime        jsonInt64       `json:"start_time"`
+	EndTime          jsonInt64       `json:"end_time"`
+}
+
+// decodeAccountValue decodes into v the JSON account value data.
+// Cosmos SDK v0.38 and v0.39 LCDs return vesting accounts flattened, while v0.37 ones
+// nest them into BaseVestingAccount and BaseAccount objects, and v0.40+ ones into
+// base_vesting_account and base_account objects: nested objects are decoded first,
+// then the outer fields on top of them.
+func decodeAccountValue(data []byte, v *accountValue) error {
+	var nested struct {
+		BaseVestingAccount      json.RawMessage `json:"BaseVestingAccount"`
+		BaseAccount             json.RawMessage `json:"BaseAccount"`
+		ProtoBaseVestingAccount json.RawMessage `json:"base_vesting_account"`
+		ProtoBaseAccount        json.RawMessage `json:"base_account"`
+	}
+
+	if err := json.Unmarshal(data, &nested); err != nil {
+		return err
+	}
+
+	for _, raw := range []json.RawMessage{
+		nested.BaseVestingAccount,
+		nested.BaseAccount,
+		nested.ProtoBaseVestingAccount,
+		nested.ProtoBaseAccount,
+	} {
+		if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+
+		if err := decodeAccountValue(raw, v); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Account.
+// It accepts both amino JSON accounts, wrapped into a type and value object, and
+// protobuf JSON accounts, carrying their type in the "@type" field.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type      string          `json:"type"`
+		ProtoType string          `json:"@type"`
+		Value     json.RawMessage `json:"value"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	valueData := raw.Value
+	accountType := raw.Type
+	if raw.ProtoType != "" {
+		valueData = data
+		accountType = raw.ProtoType
+	}
+
+	if len(valueData) == 0 || bytes.Equal(valueData, []byte("null")) {
+		*a = Account{Type: accountType}
+		return nil
+	}
+
+	var v accountValue
+	if err := decodeAccountValue(valueData, &v); err != nil {
+		return err
+	}
+
+	pubKeyData := v.PublicKey
+	if len(pubKeyData) == 0 {
+		pubKeyData = v.PubKey
+	}
+
+	pubKey, err := decodeAccountPubKey(pubKeyData)
+	if err != nil {
+		return err
+	}
+
+	*a = Account{
+		Type:          accountType,
+		Address:       v.Address,
+		Coins:         v.Coins,
+		AccountNumber: int64(v.AccountNumber),
+		Sequence:      int64(v.Sequence),
+		PubKey:        pubKey,
+	}
+
+	if v.EndTime != 0 {
+		a.Vesting = &VestingInfo{
+			OriginalVesting:  v.OriginalVesting,
+			DelegatedFree:    v.DelegatedFree,
+			DelegatedVesting: v.DelegatedVesting,
+			StartTime:        int64(v.StartTime),
+			EndTime:          int64(v.EndTime),
+		}
+	}
+
+	return nil
+}
+
+// pubKeyCodec decodes amino encoded public keys.
+var pubKeyCodec = func() *codec.Codec {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+
+	return cdc
+}()
+
+// decodeAccountPubKey decodes the public key of an account, which Cosmos SDK v0.38 and
+// v0.39 LCDs encode as a bech32 string, v0.37 ones as an amino JSON object, and v0.40+
+// ones as a protobuf JSON object.
+func decodeAccountPubKey(data json.RawMessage) (*SigPubKey, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) || bytes.Equal(data, []byte(`""`)) {
+		return nil, nil
+	}
+
+	var bech32PubKey string
+	if err := json.Unmarshal(data, &bech32PubKey); err == nil {
+		return decodeBech32PubKey(bech32PubKey)
+	}
+
+	var raw struct {
+		ProtoType  string            `json:"@type"`
+		Key        string            `json:"key"`
+		Threshold  uint              `json:"threshold"`
+		PublicKeys []json.RawMessage `json:"public_keys"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	switch raw.ProtoType {
+	case "":
+		var spk SigPubKey
+		if err := json.Unmarshal(data, &spk); err != nil {
+			return nil, err
+		}
+
+		return &spk, nil
+	case secp256k1ProtoTypeURL:
+		return &SigPubKey{Type: secp256k1PubKeyType, Value: raw.Key}, nil
+	case multisigProtoTypeURL:
+		spk := SigPubKey{Type: multisigPubKeyType, Threshold: raw.Threshold}
+
+		for _, pkData := range raw.PublicKeys {
+			pk, err := decodeAccountPubKey(pkData)
+			if err != nil {
+				return nil, err
+			}
+
+			if pk == nil {
+				return nil, ErrMalformedPubKey
+			}
+
+			spk.PubKeys = append(spk.PubKeys, *pk)
+		}
+
+		return &spk, nil
+	default:
+		return nil, ErrUnsupportedPubKeyType(raw.ProtoType)
+	}
+}
+
+// decodeBech32PubKey decodes a bech32 account public key, like the ones returned by
+// Cosmos SDK v0.38 and v0.39 LCDs.
+func decodeBech32PubKey(pubKey string) (*SigPubKey, error) {
+	_, bz, err := bech32.DecodeAndConvert(pubKey)
+	if err != nil {
+		return nil, ErrMalformedPubKey
+	}
+
+	var pubKeyTm crypto.PubKey
+	if err := pubKeyCodec.UnmarshalBinaryBare(bz, &pubKeyTm); err != nil {
+		return nil, ErrMalformedPubKey
+	}
+
+	pubKeyJSON, err := pubKeyCodec.MarshalJSON(pubKeyTm)
+	if err != nil {
+		return nil, err
+	}
+
+	var spk SigPubKey
+	if err := json.Unmarshal(pubKeyJSON, &spk); err != nil {
+		return nil, err
+	}
+
+	return &spk, nil
+}
+
+// Account retrieves the whole state of the account related to the given wallet address,
+// like its coins, public key and vesting schedule.
+// If the account has never been used on chain, an error wrapping ErrAccountNotOnline
+// is returned.
+func (c *Client) Account(ctx context.Context, address string) (Account, error) {
+	account, err := c.account(ctx, address)
+	if err != nil {
+		return Account{}, err
+	}
+
+	if c.SignMode == SignModeDirect {
+		// Cosmos SDK v0.40+ accounts don't hold coins anymore
+		if account.Coins, err = c.Balances(ctx, address); err != nil {
+			return Account{}, err
+		}
+	}
+
+	return account, nil
+}
+
+// account retrieves the account related to address from the LCD, without its coins
+// on Cosmos SDK v0.40+ chains.
+func (c *Client) account(ctx context.Context, address string) (Account, error) {
+	path := fmt.Sprintf("/auth/accounts/%s", address)
+	if c.SignMode == SignModeDirect {
+		path = fmt.Sprintf("/cosmos/auth/v1beta1/accounts/%s", address)
+	}
+
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return Account{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if c.SignMode == SignModeDirect && resp.StatusCode == http.StatusNotFound {
+		return Account{}, fmt.Errorf("%w: %s", ErrAccountNotOnline, address)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// we had an error, deserialize it and return
+		return Account{}, fmt.Errorf("error during get account data: %w", newHTTPError(resp))
+	}
+
+	var accountResp struct {
+		Result  Account `json:"result"`
+		Account Account `json:"account"`
+	}
+
+	jdec := json.NewDecoder(resp.Body)
+	if err := jdec.Decode(&accountResp); err != nil {
+		return Account{}, fmt.Errorf("could not unmarshal node response: %w", err)
+	}
+
+	account := accountResp.Result
+	if c.SignMode == SignModeDirect {
+		account = accountResp.Account
+	}
+
+	if account.Address == "" {
+		return Account{}, fmt.Errorf("%w: %s", ErrAccountNotOnline, address)
+	}
+
+	return account, nil
+}
+
+// Balances retrieves the coins held by the account related to the given wallet address.
+// Accounts which have never been used on chain hold no coins.
+func (c *Client) Balances(ctx context.Context, address string) (Coins, error) {
+	if c.SignMode == SignModeDirect {
+		return c.directBalances(ctx, address)
+	}
+
+	resp, err := c.get(ctx, fmt.Sprintf("/bank/balances/%s", address))
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error during balances query: %w", newHTTPError(resp))
+	}
+
+	var balancesResp struct {
+		Result Coins `json:"result"`
+	}
+
+	jdec := json.NewDecoder(resp.Body)
+	if err := jdec.Decode(&balancesResp); err != nil {
+		return nil, fmt.Errorf("could not unmarshal node response: %w", err)
+	}
+
+	return balancesResp.Result, nil
+}
+
+// directBalances retrieves the coins held by address from the paginated
+// /cosmos/bank/v1beta1/balances endpoint of a Cosmos SDK v0.40+ LCD, following
+// the next page key until the last page.
+func (c *Client) directBalances(ctx context.Context, address string) (Coins, error) {
+	var (
+		coins   Coins
+		nextKey string
+	)
+
+	for {
+		path := fmt.Sprintf("/cosmos/bank/v1beta1/balances/%s", address)
+		if nextKey != "" {
+			path += "?pagination.key=" + url.QueryEscape(nextKey)
+		}
+
+		page, err := c.balancesPage(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		coins = append(coins, page.Balances...)
+
+		nextKey = page.Pagination.NextKey
+		if nextKey == "" {
+			return coins, nil
+		}
+	}
+}
+
+// balancesPage is a page of the /cosmos/bank/v1beta1/balances endpoint response.
+type balancesPage struct {
+	Balances   Coins `json:"balances"`
+	Pagination struct {
+		NextKey string `json:"next_key"`
+	} `json:"pagination"`
+}
+
+// balancesPage retrieves the page of balances at path.
+func (c *Client) balancesPage(ctx context.Context, path string) (balancesPage, error) {
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return balancesPage{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return balancesPage{}, fmt.Errorf("error during balances query: %w", newHTTPError(resp))
+	}
+
+	var page balancesPage
+
+	jdec := json.NewDecoder(resp.Body)
+	if err := jdec.Decode(&page); err != nil {
+		return balancesPage{}, fmt.Errorf("could not unmarshal node response: %w", err)
+	}
+
+	return page, nil
+}
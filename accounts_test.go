@@ -0,0 +1,332 @@
+package sacco
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_UnmarshalJSON(t *testing.T) {
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	spk, err := w.SigPubKey()
+	require.NoError(t, err)
+
+	coins := Coins{{Denom: "uatom", Amount: "100"}}
+	vesting := &VestingInfo{
+		OriginalVesting:  Coins{{Denom: "uatom", Amount: "1000"}},
+		DelegatedFree:    Coins{},
+		DelegatedVesting: Coins{{Denom: "uatom", Amount: "10"}},
+		StartTime:        1577836800,
+		EndTime:          1609459200,
+	}
+
+	tests := []struct {
+		name      string
+		data      string
+		want      Account
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"base account",
+			`{"type":"cosmos-sdk/Account","value":{"address":"` + w.Address + `","coins":[{"denom":"uatom","amount":"100"}],"public_key":"` + w.PublicKeyBech32 + `","account_number":11,"sequence":3}}`,
+			Account{Type: "cosmos-sdk/Account", Address: w.Address, Coins: coins, AccountNumber: 11, Sequence: 3, PubKey: &spk},
+			assert.NoError,
+		},
+		{
+			"base account without public key",
+			`{"type":"cosmos-sdk/Account","value":{"address":"` + w.Address + `","coins":[{"denom":"uatom","amount":"100"}],"public_key":"","account_number":11,"sequence":0}}`,
+			Account{Type: "cosmos-sdk/Account", Address: w.Address, Coins: coins, AccountNumber: 11},
+			assert.NoError,
+		},
+		{
+			"continuous vesting account",
+			`{"type":"cosmos-sdk/ContinuousVestingAccount","value":{"address":"` + w.Address + `","coins":[{"denom":"uatom","amount":"100"}],"public_key":"` + w.PublicKeyBech32 + `","account_number":11,"sequence":3,"original_vesting":[{"denom":"uatom","amount":"1000"}],"delegated_free":[],"delegated_vesting":[{"denom":"uatom","amount":"10"}],"end_time":1609459200,"start_time":1577836800}}`,
+			Account{Type: "cosmos-sdk/ContinuousVestingAccount", Address: w.Address, Coins: coins, AccountNumber: 11, Sequence: 3, PubKey: &spk, Vesting: vesting},
+			assert.NoError,
+		},
+		{
+			"nested delayed vesting account",
+			`{"type":"cosmos-sdk/DelayedVestingAccount","value":{"BaseVestingAccount":{"BaseAccount":{"address":"` + w.Address + `","coins":[{"denom":"uatom","amount":"100"}],"public_key":{"type":"tendermint/PubKeySecp256k1","value":"` + spk.Value + `"},"account_number":"11","sequence":"3"},"original_vesting":[{"denom":"uatom","amount":"1000"}],"delegated_free":[],"delegated_vesting":[{"denom":"uatom","amount":"10"}],"end_time":"1609459200"}}}`,
+			Account{
+				Type:          "cosmos-sdk/DelayedVestingAccount",
+				Address:       w.Address,
+				Coins:         coins,
+				AccountNumber: 11,
+				Sequence:      3,
+				PubKey:        &spk,
+				Vesting: &VestingInfo{
+					OriginalVesting:  vesting.OriginalVesting,
+					DelegatedFree:    vesting.DelegatedFree,
+					DelegatedVesting: vesting.DelegatedVesting,
+					EndTime:          vesting.EndTime,
+				},
+			},
+			assert.NoError,
+		},
+		{
+			"protobuf continuous vesting account",
+			`{"@type":"/cosmos.vesting.v1beta1.ContinuousVestingAccount","base_vesting_account":{"base_account":{"address":"` + w.Address + `","pub_key":{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"` + spk.Value + `"},"account_number":"11","sequence":"3"},"original_vesting":[{"denom":"uatom","amount":"1000"}],"delegated_free":[],"delegated_vesting":[{"denom":"uatom","amount":"10"}],"end_time":"1609459200"},"start_time":"1577836800"}`,
+			Account{Type: "/cosmos.vesting.v1beta1.ContinuousVestingAccount", Address: w.Address, AccountNumber: 11, Sequence: 3, PubKey: &spk, Vesting: vesting},
+			assert.NoError,
+		},
+		{
+			"invalid public key",
+			`{"type":"cosmos-sdk/Account","value":{"address":"` + w.Address + `","public_key":"invalid","account_number":11,"sequence":3}}`,
+			Account{},
+			assert.Error,
+		},
+		{
+			"unsupported public key type",
+			`{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"` + w.Address + `","pub_key":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"` + spk.Value + `"},"account_number":"11","sequence":"3"}`,
+			Account{},
+			assert.Error,
+		},
+		{
+			"invalid account number",
+			`{"type":"cosmos-sdk/Account","value":{"address":"` + w.Address + `","account_number":"eleven","sequence":3}}`,
+			Account{},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Account
+			err := json.Unmarshal([]byte(tt.data), &got)
+
+			tt.assertion(t, err)
+			if err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAccount_UnmarshalJSON_MultisigPubKey(t *testing.T) {
+	pubKeys := make([]SigPubKey, 2)
+	for i, path := range []string{"m/44'/118'/0'/0/0", "m/44'/118'/0'/0/1"} {
+		w, err := FromMnemonic("cosmos", testMnemonic, path)
+		require.NoError(t, err)
+
+		pubKeys[i], err = w.SigPubKey()
+		require.NoError(t, err)
+	}
+
+	multisig, err := NewMultisigPubKey(2, pubKeys...)
+	require.NoError(t, err)
+
+	data := `{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"` + testAccAddress + `","pub_key":{"@type":"/cosmos.crypto.multisig.LegacyAminoPubKey","threshold":2,"public_keys":[` +
+		`{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"` + pubKeys[0].Value + `"},` +
+		`{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"` + pubKeys[1].Value + `"}]},"account_number":"11","sequence":"3"}`
+
+	var got Account
+	require.NoError(t, json.Unmarshal([]byte(data), &got))
+	assert.Equal(t, &multisig, got.PubKey)
+}
+
+func TestClient_Account(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	tests := []struct {
+		name       string
+		jsonResp   string
+		statusResp int
+		want       Account
+		wantErr    error
+	}{
+		{
+			"live account",
+			`{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"` + testAccAddress + `","coins":[{"denom":"uatom","amount":"10"}],"public_key":"","account_number":11,"sequence":2}}}`,
+			http.StatusOK,
+			Account{Type: "cosmos-sdk/Account", Address: testAccAddress, Coins: Coins{{Denom: "uatom", Amount: "10"}}, AccountNumber: 11, Sequence: 2},
+			nil,
+		},
+		{
+			"non-live account",
+			`{"height":"1809","result":{"type":"cosmos-sdk/Account","value":{"address":"","coins":[],"public_key":null,"account_number":0,"sequence":0}}}`,
+			http.StatusOK,
+			Account{},
+			ErrAccountNotOnline,
+		},
+		{
+			"error response",
+			`{"error":"decoding bech32 failed: invalid index of 1"}`,
+			http.StatusInternalServerError,
+			Account{},
+			&HTTPError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+testAccAddress,
+				httpmock.NewStringResponder(tt.statusResp, tt.jsonResp))
+
+			got, err := NewClient(mockHTTPEndpoint, nil, nil).Account(context.Background(), testAccAddress)
+
+			switch wantErr := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+			case *HTTPError:
+				assert.True(t, errors.As(err, &wantErr))
+			default:
+				assert.True(t, errors.Is(err, wantErr))
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_Account_SignModeDirect(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/auth/v1beta1/accounts/"+testAccAddress,
+		httpmock.NewStringResponder(http.StatusOK, `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"`+testAccAddress+`","pub_key":null,"account_number":"11","sequence":"2"}}`))
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/bank/v1beta1/balances/"+testAccAddress,
+		httpmock.NewStringResponder(http.StatusOK, `{"balances":[{"denom":"uatom","amount":"10"}],"pagination":{"next_key":null,"total":"1"}}`))
+
+	c := NewClient(mockHTTPEndpoint, nil, nil)
+	c.SignMode = SignModeDirect
+
+	got, err := c.Account(context.Background(), testAccAddress)
+	require.NoError(t, err)
+	assert.Equal(t, Account{
+		Type:          "/cosmos.auth.v1beta1.BaseAccount",
+		Address:       testAccAddress,
+		Coins:         Coins{{Denom: "uatom", Amount: "10"}},
+		AccountNumber: 11,
+		Sequence:      2,
+	}, got)
+}
+
+func TestClient_AccountData_VestingAccount(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+testAccAddress,
+		httpmock.NewStringResponder(http.StatusOK, `{"height":"1590","result":{"type":"cosmos-sdk/DelayedVestingAccount","value":{"BaseVestingAccount":{"BaseAccount":{"address":"`+testAccAddress+`","coins":[],"public_key":null,"account_number":"11","sequence":"2"},"original_vesting":[],"delegated_free":[],"delegated_vesting":[],"end_time":"1609459200"}}}}`))
+
+	got, err := NewClient(mockHTTPEndpoint, nil, nil).AccountData(context.Background(), testAccAddress)
+	require.NoError(t, err)
+	assert.Equal(t, AccountDataValue{Address: testAccAddress, AccountNumber: 11, Sequence: 2}, got.Result.Value)
+}
+
+func TestClient_Balances(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	tests := []struct {
+		name       string
+		signMode   SignMode
+		path       string
+		jsonResp   string
+		statusResp int
+		want       Coins
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"legacy amino",
+			SignModeLegacyAmino,
+			"/bank/balances/",
+			`{"height":"1590","result":[{"denom":"uatom","amount":"10"},{"denom":"ucommercio","amount":"5"}]}`,
+			http.StatusOK,
+			Coins{{Denom: "uatom", Amount: "10"}, {Denom: "ucommercio", Amount: "5"}},
+			assert.NoError,
+		},
+		{
+			"empty account",
+			SignModeLegacyAmino,
+			"/bank/balances/",
+			`{"height":"1590","result":[]}`,
+			http.StatusOK,
+			Coins{},
+			assert.NoError,
+		},
+		{
+			"direct",
+			SignModeDirect,
+			"/cosmos/bank/v1beta1/balances/",
+			`{"balances":[{"denom":"uatom","amount":"10"}],"pagination":{"next_key":null,"total":"1"}}`,
+			http.StatusOK,
+			Coins{{Denom: "uatom", Amount: "10"}},
+			assert.NoError,
+		},
+		{
+			"error response",
+			SignModeLegacyAmino,
+			"/bank/balances/",
+			`{"error":"decoding bech32 failed: invalid index of 1"}`,
+			http.StatusBadRequest,
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+tt.path+testAccAddress,
+				httpmock.NewStringResponder(tt.statusResp, tt.jsonResp))
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			c.SignMode = tt.signMode
+
+			got, err := c.Balances(context.Background(), testAccAddress)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_Balances_pagination(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	pages := map[string]string{
+		"":                 `{"balances":[{"denom":"uatom","amount":"10"}],"pagination":{"next_key":"dWNvbW1lcmNpbw==","total":"0"}}`,
+		"dWNvbW1lcmNpbw==": `{"balances":[{"denom":"ucommercio","amount":"5"}],"pagination":{"next_key":"dXhwcg==","total":"0"}}`,
+		"dXhwcg==":         `{"balances":[{"denom":"uxpr","amount":"1"}],"pagination":{"next_key":null,"total":"0"}}`,
+	}
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/bank/v1beta1/balances/"+testAccAddress,
+		func(req *http.Request) (*http.Response, error) {
+			page, ok := pages[req.URL.Query().Get("pagination.key")]
+			if !ok {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"code":3,"message":"invalid key"}`), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusOK, page), nil
+		})
+
+	c := NewClient(mockHTTPEndpoint, nil, nil)
+	c.SignMode = SignModeDirect
+
+	got, err := c.Balances(context.Background(), testAccAddress)
+	require.NoError(t, err)
+	assert.Equal(t, Coins{
+		{Denom: "uatom", Amount: "10"},
+		{Denom: "ucommercio", Amount: "5"},
+		{Denom: "uxpr", Amount: "1"},
+	}, got)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 3, calls["GET "+mockHTTPEndpoint+"/cosmos/bank/v1beta1/balances/"+testAccAddress])
+}
@@ -0,0 +1,28 @@
+package sacco
+
+import "context"
+
+// Backend is a node transactions can be signed against and broadcast to.
+// It is implemented both by Client, which talks to a Cosmos LCD REST service,
+// and by RPCClient, which talks to a Tendermint JSON-RPC service.
+type Backend interface {
+	// NodeInfo returns useful information of the node, like the Network
+	// (chain) name.
+	NodeInfo(ctx context.Context) (NodeInfo, error)
+
+	// AccountData retrieves the account data related to the given wallet address,
+	// like account number and sequence number.
+	// If the account has never been used on chain, an error wrapping
+	// ErrAccountNotOnline is returned.
+	AccountData(ctx context.Context, address string) (AccountData, error)
+
+	// BroadcastTx broadcasts tx, and returns the whole TxResponse the node replied with.
+	// If the transaction has been rejected by the chain, both the TxResponse and an error
+	// are returned.
+	BroadcastTx(ctx context.Context, tx SignedTransactionPayload, txMode TxMode) (TxResponse, error)
+}
+
+var (
+	_ Backend = (*Client)(nil)
+	_ Backend = (*RPCClient)(nil)
+)
@@ -3,7 +3,7 @@ package sacco
 //go:generate stringer -type=TxMode
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -32,10 +32,11 @@ const (
 	ModeBlock TxMode = "block"
 )
 
-// broadcastTx broadcasts a tx to the Cosmos LCD identified by lcdEndpoint.
-func broadcastTx(tx SignedTransactionPayload, lcdEndpoint string, txMode TxMode) (string, error) {
-	endpoint := fmt.Sprintf("%s/txs", lcdEndpoint)
-
+// BroadcastTx broadcasts a tx to the Cosmos LCD c is connected to, and returns the
+// whole TxResponse the LCD replied with.
+// If the transaction has been rejected by the chain, both the TxResponse and an error
+// are returned.
+func (c *Client) BroadcastTx(ctx context.Context, tx SignedTransactionPayload, txMode TxMode) (TxResponse, error) {
 	// assemble a tx transaction
 	txBody := TxBody{
 		Tx:   tx,
@@ -45,13 +46,26 @@ func broadcastTx(tx SignedTransactionPayload, lcdEndpoint string, txMode TxMode)
 	cdc := codec.New()
 	requestBody, err := cdc.MarshalJSON(txBody)
 	if err != nil {
-		return "", err
+		return TxResponse{}, err
 	}
 
-	// send tx to lcdEndpoint
-	resp, err := http.Post(endpoint, "application/json", bytes.NewBuffer(requestBody))
+	var txr TxResponse
+
+	err = retry(ctx, c.BroadcastRetry, IsRetryableBroadcastError, func() error {
+		txr, err = c.broadcastTx(ctx, requestBody)
+		return err
+	})
+
+	return txr, err
+}
+
+// broadcastTx posts the amino JSON TxBody requestBody to the LCD, and returns the
+// TxResponse it replied with.
+func (c *Client) broadcastTx(ctx context.Context, requestBody []byte) (TxResponse, error) {
+	// send tx to the LCD
+	resp, err := c.post(ctx, "/txs", requestBody)
 	if err != nil {
-		return "", err
+		return TxResponse{}, err
 	}
 
 	defer resp.Body.Close()
@@ -59,14 +73,7 @@ func broadcastTx(tx SignedTransactionPayload, lcdEndpoint string, txMode TxMode)
 	// we had a serious problem with the LCD request,
 	// decode the json error and return its value to caller
 	if resp.StatusCode != http.StatusOK {
-		var jerr Error
-		jd := json.NewDecoder(resp.Body)
-		err := jd.Decode(&jerr)
-		if err != nil {
-			return "", fmt.Errorf("could not process error json decoding: %w", err)
-		}
-
-		return "", fmt.Errorf("error while processing tx send request: %s", jerr.Error)
+		return TxResponse{}, fmt.Errorf("error while processing tx send request: %w", newHTTPError(resp))
 	}
 
 	// deserialize LCD response into a cosmos TxResponse
@@ -76,52 +83,114 @@ func broadcastTx(tx SignedTransactionPayload, lcdEndpoint string, txMode TxMode)
 
 	err = jdec.Decode(&txr)
 	if err != nil {
-		return "", fmt.Errorf("could not deserialize cosmos txresponse from lcd: %w", err)
+		return TxResponse{}, fmt.Errorf("could not deserialize cosmos txresponse from lcd: %w", err)
 	}
 
-	if txr.Code != 0 {
-		return "", fmt.Errorf(
-			"codespace %s: %s, code %d",
-			txr.Codespace,
-			txr.RawLog,
-			txr.Code,
-		)
+	return txr, txResponseError(txr)
+}
+
+// txResponseError returns a *TxError describing why txr has been rejected by the chain,
+// or nil if txr has been accepted.
+func txResponseError(txr TxResponse) error {
+	if txr.Code == 0 {
+		return nil
 	}
 
-	return txr.TxHash, nil
+	return &TxError{
+		Code:      txr.Code,
+		Codespace: txr.Codespace,
+		RawLog:    txr.RawLog,
+		TxHash:    txr.TxHash,
+	}
 }
 
 // SignAndBroadcast signs tx and broadcast it to the LCD specified by lcdEndpoint.
 func (w *Wallet) SignAndBroadcast(tx TransactionPayload, lcdEndpoint string, txMode TxMode) (string, error) {
+	return w.SignAndBroadcastWithClient(context.Background(), NewClient(lcdEndpoint, nil, nil), tx, txMode)
+}
+
+// SignAndBroadcastWithClient signs tx and broadcast it to the node client is connected to,
+// which can be either a Client or an RPCClient.
+// Every request is bound to ctx.
+func (w *Wallet) SignAndBroadcastWithClient(ctx context.Context, client Backend, tx TransactionPayload, txMode TxMode) (string, error) {
+	txr, err := w.SignAndBroadcastWithResponse(ctx, client, tx, txMode)
+	if err != nil {
+		return "", err
+	}
+
+	// return transaction hash!
+	return txr.TxHash, nil
+}
+
+// SignAndBroadcastWithResponse signs tx and broadcast it to the node client is connected to,
+// returning the whole TxResponse, logs and events included.
+// Every request is bound to ctx.
+func (w *Wallet) SignAndBroadcastWithResponse(ctx context.Context, client Backend, tx TransactionPayload, txMode TxMode) (TxResponse, error) {
 	// get network (chain) name
-	nodeInfo, err := getNodeInfo(lcdEndpoint)
+	nodeInfo, err := client.NodeInfo(ctx)
 	if err != nil {
-		return "", fmt.Errorf("could not get LCD node informations: %w", err)
+		return TxResponse{}, fmt.Errorf("could not get LCD node informations: %w", err)
 	}
 
 	// get account sequence and account number
-	accountData, err := getAccountData(lcdEndpoint, w.Address)
+	accountData, err := client.AccountData(ctx, w.Address)
 	if err != nil {
-		return "", fmt.Errorf("could not get Account informations for address %s: %w", w.Address, err)
+		return TxResponse{}, fmt.Errorf("could not get Account informations for address %s: %w", w.Address, err)
+	}
+
+	return w.signAndBroadcast(
+		ctx,
+		client,
+		tx,
+		nodeInfo.Info.Network,
+		accountData.Result.Value.AccountNumber,
+		accountData.Result.Value.Sequence,
+		txMode,
+	)
+}
+
+// signAndBroadcast signs tx with the given chainID, accountNumber and sequence, and
+// broadcasts it to the node client is connected to.
+// If client is a Client with SignModeDirect, tx is signed and broadcast as a protobuf
+// transaction, otherwise as an amino one.
+func (w *Wallet) signAndBroadcast(
+	ctx context.Context,
+	client Backend,
+	tx TransactionPayload,
+	chainID string,
+	accountNumber, sequence int64,
+	txMode TxMode,
+) (TxResponse, error) {
+	if c, ok := client.(*Client); ok && c.SignMode == SignModeDirect {
+		txRaw, err := w.SignDirect(tx, chainID, uint64(accountNumber), uint64(sequence))
+		if err != nil {
+			return TxResponse{}, fmt.Errorf("could not sign transaction: %w", err)
+		}
+
+		txr, err := c.BroadcastTxRaw(ctx, txRaw, txMode)
+		if err != nil {
+			return txr, fmt.Errorf("could not broadcast transaction to the Cosmos network: %w", err)
+		}
+
+		return txr, nil
 	}
 
 	// sign transaction
 	signedTx, err := w.Sign(
 		tx,
-		nodeInfo.Info.Network,
-		strconv.FormatInt(accountData.Result.Value.AccountNumber, 10),
-		strconv.FormatInt(accountData.Result.Value.Sequence, 10),
+		chainID,
+		strconv.FormatInt(accountNumber, 10),
+		strconv.FormatInt(sequence, 10),
 	)
 	if err != nil {
-		return "", fmt.Errorf("could not sign transaction: %w", err)
+		return TxResponse{}, fmt.Errorf("could not sign transaction: %w", err)
 	}
 
 	// broadcast transaction to the LCD
-	txHash, err := broadcastTx(signedTx, lcdEndpoint, txMode)
+	txr, err := client.BroadcastTx(ctx, signedTx, txMode)
 	if err != nil {
-		return "", fmt.Errorf("could not broadcast transaction to the Cosmos network: %w", err)
+		return txr, fmt.Errorf("could not broadcast transaction to the Cosmos network: %w", err)
 	}
 
-	// return transaction hash!
-	return txHash, nil
+	return txr, nil
 }
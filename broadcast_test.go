@@ -0,0 +1,95 @@
+package sacco
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	sdkTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_BroadcastTx(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	tests := []struct {
+		name       string
+		jsonResp   string
+		statusResp int
+		want       TxResponse
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"transaction included in a block",
+			`{"height":"1590","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","raw_log":"[{\"msg_index\":0,\"log\":\"\",\"events\":[{\"type\":\"message\",\"attributes\":[{\"key\":\"action\",\"value\":\"send\"}]}]}]","logs":[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"send"}]}]}],"gas_wanted":"200000","gas_used":"52541"}`,
+			http.StatusOK,
+			TxResponse{
+				Height: "1590",
+				TxHash: "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				RawLog: `[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"send"}]}]}]`,
+				Logs: sdkTypes.ABCIMessageLogs{
+					{
+						MsgIndex: 0,
+						Log:      "",
+						Events: sdkTypes.StringEvents{
+							{
+								Type: "message",
+								Attributes: []sdkTypes.Attribute{
+									{Key: "action", Value: "send"},
+								},
+							},
+						},
+					},
+				},
+				GasWanted: "200000",
+				GasUsed:   "52541",
+			},
+			assert.NoError,
+		},
+		{
+			"transaction rejected by the chain",
+			`{"height":"0","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","codespace":"sdk","code":5,"raw_log":"insufficient funds","gas_wanted":"200000","gas_used":"31231"}`,
+			http.StatusOK,
+			TxResponse{
+				Height:    "0",
+				TxHash:    "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				Codespace: "sdk",
+				Code:      5,
+				RawLog:    "insufficient funds",
+				GasWanted: "200000",
+				GasUsed:   "31231",
+			},
+			assert.Error,
+		},
+		{
+			"unsuccessful request with a JSON error",
+			`{"error":"invalid mode"}`,
+			http.StatusBadRequest,
+			TxResponse{},
+			assert.Error,
+		},
+		{
+			"unsuccessful request with a malformed error",
+			`malformed error`,
+			http.StatusInternalServerError,
+			TxResponse{},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/txs",
+				httpmock.NewStringResponder(tt.statusResp, tt.jsonResp))
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			got, err := c.BroadcastTx(context.Background(), SignedTransactionPayload{}, ModeBlock)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package sacco
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the timeout applied to each LCD request when a Client
+// is built without an explicit *http.Client.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultPollInterval is the interval between two LCD queries issued by
+// Client.WaitForTx, when the Client has no PollInterval.
+const DefaultPollInterval = time.Second
+
+// Client is a facility used to talk to a Cosmos LCD REST service.
+// Every request issued by a Client is bound to a context.Context, so
+// callers can cancel, time out and instrument each of them.
+//
+// A Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	// Endpoint is the base URL of the LCD, e.g. "http://localhost:1317".
+	Endpoint string
+
+	// HTTPClient is the *http.Client used to issue requests.
+	HTTPClient *http.Client
+
+	// Header holds the default headers sent along every request.
+	Header http.Header
+
+	// PollInterval is the interval between two LCD queries issued by WaitForTx.
+	// If zero, DefaultPollInterval is used.
+	PollInterval time.Duration
+
+	// SignMode is the way transactions signed through the Client are encoded and
+	// signed, which depends on the Cosmos SDK version of the chain.
+	// If zero, SignModeLegacyAmino is used.
+	SignMode SignMode
+
+	// Pool is the set of LCD endpoints requests are sent to, in place of Endpoint.
+	// If nil, Endpoint is used.
+	Pool *EndpointPool
+
+	// QueryRetry is the RetryPolicy applied to LCD queries.
+	// If nil, failed queries aren't retried.
+	QueryRetry *RetryPolicy
+
+	// BroadcastRetry is the RetryPolicy applied to transaction broadcasts.
+	// If nil, failed broadcasts aren't retried.
+	BroadcastRetry *RetryPolicy
+}
+
+// NewClient returns a new Client instance given an LCD endpoint, an *http.Client and
+// a set of default headers.
+// If httpClient is nil, a new *http.Client with DefaultTimeout is used.
+func NewClient(endpoint string, httpClient *http.Client, header http.Header) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: DefaultTimeout,
+		}
+	}
+
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &Client{
+		Endpoint:   endpoint,
+		HTTPClient: httpClient,
+		Header:     header,
+	}
+}
+
+// get issues a GET request to path, relative to c's Endpoint, retrying it according
+// to c's QueryRetry.
+// Once retries are over, the last response is returned even if its status isn't 200 OK.
+func (c *Client) get(ctx context.Context, path string) (*http.Response, error) {
+	var resp *http.Response
+
+	err := retry(ctx, c.QueryRetry, IsRetryableQueryError, func() error {
+		var err error
+
+		resp, err = c.do(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return err
+		}
+
+		if c.QueryRetry != nil && isRetryableStatus(resp.StatusCode) {
+			return bufferHTTPError(resp)
+		}
+
+		return nil
+	})
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return resp, nil
+	}
+
+	return resp, err
+}
+
+// post issues a POST request to path, relative to c's Endpoint, with body
+// marshaled as the JSON request body.
+func (c *Client) post(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, path, body)
+}
+
+// do builds and sends an HTTP request with c's default headers.
+// If c has a Pool, the request is sent to the pool endpoints instead of c's Endpoint.
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	if c.Pool != nil {
+		return c.doPool(ctx, method, path, body)
+	}
+
+	return doRequest(ctx, c.HTTPClient, c.Header, method, fmt.Sprintf("%s%s", c.Endpoint, path), bodyReader(body))
+}
+
+// bodyReader returns a reader over body, or nil if body is nil.
+func bodyReader(body []byte) io.Reader {
+	if body == nil {
+		return nil
+	}
+
+	return bytes.NewReader(body)
+}
+
+// doRequest builds and sends an HTTP request to url with httpClient, adding header
+// to the request headers.
+// If httpClient is nil, http.DefaultClient is used.
+func doRequest(ctx context.Context, httpClient *http.Client, header http.Header, method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return httpClient.Do(req)
+}
+
+// newHTTPError builds an *HTTPError out of resp, decoding the JSON error an LCD
+// sends whenever something goes wrong during the handler processing.
+func newHTTPError(resp *http.Response) *HTTPError {
+	httpErr := &HTTPError{
+		StatusCode: resp.StatusCode,
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return httpErr
+	}
+
+	httpErr.Body = string(body)
+
+	var jerr Error
+	if err := json.Unmarshal(body, &jerr); err == nil {
+		httpErr.Message = jerr.Error
+	}
+
+	return httpErr
+}
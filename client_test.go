@@ -0,0 +1,66 @@
+package sacco
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	c := NewClient("http://127.0.0.1:3333", nil, nil)
+
+	assert.Equal(t, "http://127.0.0.1:3333", c.Endpoint)
+	assert.Equal(t, DefaultTimeout, c.HTTPClient.Timeout)
+	assert.NotNil(t, c.Header)
+
+	hc := &http.Client{}
+	c = NewClient("http://127.0.0.1:3333", hc, nil)
+	assert.Same(t, hc, c.HTTPClient)
+}
+
+func TestClient_do(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"no default headers",
+			nil,
+			assert.NoError,
+		},
+		{
+			"default headers are sent along the request",
+			http.Header{"X-Api-Key": []string{"secret"}},
+			assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/node_info",
+				func(req *http.Request) (*http.Response, error) {
+					for key := range tt.header {
+						assert.Equal(t, tt.header.Get(key), req.Header.Get(key))
+					}
+
+					return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+				})
+
+			c := NewClient(mockHTTPEndpoint, nil, tt.header)
+			resp, err := c.get(context.Background(), "/node_info")
+			tt.assertion(t, err)
+
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+		})
+	}
+}
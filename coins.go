@@ -0,0 +1,415 @@
+package sacco
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	reDnmString = `[a-z][a-z0-9]{2,15}`
+	reAmt       = `[[:digit:]]+`
+	reDecAmt    = `[[:digit:]]*\.?[[:digit:]]+`
+	reSpc       = `[[:space:]]*`
+	reDnm       = regexp.MustCompile(fmt.Sprintf(`^%s$`, reDnmString))
+	reAmount    = regexp.MustCompile(fmt.Sprintf(`^%s$`, reAmt))
+	reDecAmount = regexp.MustCompile(fmt.Sprintf(`^%s$`, reDecAmt))
+	reCoin      = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reAmt, reSpc, reDnmString))
+	reDecCoin   = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reDecAmt, reSpc, reDnmString))
+)
+
+// ValidateDenom returns an error if denom isn't a valid Cosmos SDK coin denomination.
+func ValidateDenom(denom string) error {
+	if !reDnm.MatchString(denom) {
+		return ErrInvalidDenom(denom)
+	}
+
+	return nil
+}
+
+// NewCoin returns a new Coin instance given a denomination and an amount.
+func NewCoin(denom string, amount *big.Int) Coin {
+	return Coin{
+		Denom:  denom,
+		Amount: amount.String(),
+	}
+}
+
+// ParseCoin parses a coin string like "100ucommercio".
+func ParseCoin(coinStr string) (Coin, error) {
+	matches := reCoin.FindStringSubmatch(strings.TrimSpace(coinStr))
+	if matches == nil {
+		return Coin{}, ErrInvalidCoin(coinStr)
+	}
+
+	return Coin{
+		Denom:  matches[2],
+		Amount: matches[1],
+	}, nil
+}
+
+// ParseCoins parses a comma-separated list of coins like "100ucommercio,5uccc",
+// and returns them in their canonical form, as NewCoins does.
+func ParseCoins(coinsStr string) (Coins, error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if coinsStr == "" {
+		return Coins{}, nil
+	}
+
+	rawCoins := strings.Split(coinsStr, ",")
+	coins := make(Coins, len(rawCoins))
+
+	for i, rawCoin := range rawCoins {
+		coin, err := ParseCoin(rawCoin)
+		if err != nil {
+			return nil, err
+		}
+
+		coins[i] = coin
+	}
+
+	return NewCoins(coins...)
+}
+
+// NewCoins returns coins in their canonical form: sorted by denomination,
+// with the amounts of coins sharing the same denomination summed together,
+// and without zero coins.
+func NewCoins(coins ...Coin) (Coins, error) {
+	amounts := map[string]*big.Int{}
+
+	for _, coin := range coins {
+		if err := coin.Validate(); err != nil {
+			return nil, err
+		}
+
+		amount, ok := amounts[coin.Denom]
+		if !ok {
+			amount = new(big.Int)
+			amounts[coin.Denom] = amount
+		}
+
+		amount.Add(amount, coin.amount())
+	}
+
+	res := Coins{}
+	for denom, amount := range amounts {
+		if amount.Sign() == 0 {
+			continue
+		}
+
+		res = append(res, NewCoin(denom, amount))
+	}
+
+	return res.Sort(), nil
+}
+
+// parseAmount parses amount as a non-negative integer.
+func parseAmount(amount string) (*big.Int, error) {
+	if !reAmount.MatchString(amount) {
+		return nil, ErrInvalidCoinAmount(amount)
+	}
+
+	res, _ := new(big.Int).SetString(amount, 10)
+
+	return res, nil
+}
+
+// amount returns c's amount as a *big.Int.
+// Like the Cosmos SDK does, it panics if c's amount isn't a non-negative integer:
+// coins which didn't come from ParseCoin or NewCoin must be validated first.
+func (c Coin) amount() *big.Int {
+	amount, err := parseAmount(c.Amount)
+	if err != nil {
+		panic(err)
+	}
+
+	return amount
+}
+
+// Validate returns an error if c has an invalid denomination, or an amount which isn't
+// a non-negative integer.
+func (c Coin) Validate() error {
+	if err := ValidateDenom(c.Denom); err != nil {
+		return err
+	}
+
+	_, err := parseAmount(c.Amount)
+	return err
+}
+
+// String implements the stringer interface for Coin.
+func (c Coin) String() string {
+	return c.Amount + c.Denom
+}
+
+// IsZero returns true whether c's amount is zero.
+// It panics if c's amount isn't a non-negative integer.
+func (c Coin) IsZero() bool {
+	return c.amount().Sign() == 0
+}
+
+// IsGTE returns true whether c and other share the same denomination, and c's amount
+// is greater than or equal to other's amount.
+// It panics if any of the amounts isn't a non-negative integer.
+func (c Coin) IsGTE(other Coin) bool {
+	return c.Denom == other.Denom && c.amount().Cmp(other.amount()) >= 0
+}
+
+// Add returns the sum of c and other, which must share the same denomination.
+func (c Coin) Add(other Coin) (Coin, error) {
+	if err := validateCoinPair(c, other); err != nil {
+		return Coin{}, err
+	}
+
+	return NewCoin(c.Denom, new(big.Int).Add(c.amount(), other.amount())), nil
+}
+
+// Sub returns the difference between c and other, which must share the same denomination.
+// An error is returned if the result would be negative.
+func (c Coin) Sub(other Coin) (Coin, error) {
+	if err := validateCoinPair(c, other); err != nil {
+		return Coin{}, err
+	}
+
+	res := new(big.Int).Sub(c.amount(), other.amount())
+	if res.Sign() < 0 {
+		return Coin{}, ErrNegativeCoins(c.String(), other.String())
+	}
+
+	return NewCoin(c.Denom, res), nil
+}
+
+// validateCoinPair returns an error if c or other is invalid, or if they don't share
+// the same denomination.
+func validateCoinPair(c, other Coin) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	if err := other.Validate(); err != nil {
+		return err
+	}
+
+	if c.Denom != other.Denom {
+		return ErrDenomMismatch(c.Denom, other.Denom)
+	}
+
+	return nil
+}
+
+// Validate returns an error if any of the coins in cs is invalid, or if cs
+// isn't in its canonical form.
+func (cs Coins) Validate() error {
+	for i, coin := range cs {
+		if err := coin.Validate(); err != nil {
+			return err
+		}
+
+		if coin.IsZero() {
+			return ErrInvalidCoinAmount(coin.Amount)
+		}
+
+		if i > 0 && cs[i-1].Denom >= coin.Denom {
+			return ErrCoinsNotSorted(cs.String())
+		}
+	}
+
+	return nil
+}
+
+// String implements the stringer interface for Coins.
+func (cs Coins) String() string {
+	strs := make([]string, len(cs))
+	for i, coin := range cs {
+		strs[i] = coin.String()
+	}
+
+	return strings.Join(strs, ",")
+}
+
+// Sort sorts cs by denomination, and returns it.
+func (cs Coins) Sort() Coins {
+	sort.SliceStable(cs, func(i, j int) bool {
+		return cs[i].Denom < cs[j].Denom
+	})
+
+	return cs
+}
+
+// AmountOf returns the amount of the denom coin in cs.
+// It panics if the amount of any of the denom coins isn't a non-negative integer.
+func (cs Coins) AmountOf(denom string) *big.Int {
+	amount := new(big.Int)
+	for _, coin := range cs {
+		if coin.Denom == denom {
+			amount.Add(amount, coin.amount())
+		}
+	}
+
+	return amount
+}
+
+// IsZero returns true whether cs holds no coins, or only zero coins.
+// It panics if the amount of any of the coins isn't a non-negative integer.
+func (cs Coins) IsZero() bool {
+	for _, coin := range cs {
+		if !coin.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsGTE returns true whether cs holds at least the amount of each coin in other.
+// It panics if the amount of any of the coins isn't a non-negative integer.
+func (cs Coins) IsGTE(other Coins) bool {
+	for _, coin := range other {
+		if cs.AmountOf(coin.Denom).Cmp(coin.amount()) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Add returns the sum of cs and other, in canonical form.
+func (cs Coins) Add(other Coins) (Coins, error) {
+	return NewCoins(append(append(Coins{}, cs...), other...)...)
+}
+
+// Sub returns the difference between cs and other, in canonical form.
+// An error is returned if any of the resulting amounts would be negative.
+func (cs Coins) Sub(other Coins) (Coins, error) {
+	res, err := NewCoins(cs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, coin := range other {
+		if err := coin.Validate(); err != nil {
+			return nil, err
+		}
+
+		found := false
+		for i := range res {
+			if res[i].Denom != coin.Denom {
+				continue
+			}
+
+			found = true
+			if res[i], err = res[i].Sub(coin); err != nil {
+				return nil, ErrNegativeCoins(cs.String(), other.String())
+			}
+		}
+
+		if !found && !coin.IsZero() {
+			return nil, ErrNegativeCoins(cs.String(), other.String())
+		}
+	}
+
+	return NewCoins(res...)
+}
+
+// DecCoin is an entity describing a token with a decimal amount, like a gas price.
+type DecCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// DecCoins is a set of DecCoin.
+type DecCoins []DecCoin
+
+// ParseDecCoin parses a decimal coin string like "0.025ucommercio".
+func ParseDecCoin(coinStr string) (DecCoin, error) {
+	matches := reDecCoin.FindStringSubmatch(strings.TrimSpace(coinStr))
+	if matches == nil {
+		return DecCoin{}, ErrInvalidCoin(coinStr)
+	}
+
+	return DecCoin{
+		Denom:  matches[2],
+		Amount: matches[1],
+	}, nil
+}
+
+// ParseDecCoins parses a comma-separated list of decimal coins like "0.025ucommercio,0.1uccc",
+// and returns them sorted by denomination.
+func ParseDecCoins(coinsStr string) (DecCoins, error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if coinsStr == "" {
+		return DecCoins{}, nil
+	}
+
+	rawCoins := strings.Split(coinsStr, ",")
+	coins := make(DecCoins, len(rawCoins))
+
+	for i, rawCoin := range rawCoins {
+		coin, err := ParseDecCoin(rawCoin)
+		if err != nil {
+			return nil, err
+		}
+
+		coins[i] = coin
+	}
+
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].Denom < coins[j].Denom
+	})
+
+	for i := 1; i < len(coins); i++ {
+		if coins[i-1].Denom == coins[i].Denom {
+			return nil, ErrDuplicateDenom(coins[i].Denom)
+		}
+	}
+
+	return coins, nil
+}
+
+// Rat returns d's amount as a *big.Rat.
+// Only plain decimal amounts like "0.025" are accepted, as the LCD does.
+func (d DecCoin) Rat() (*big.Rat, error) {
+	if !reDecAmount.MatchString(d.Amount) {
+		return nil, ErrInvalidCoinAmount(d.Amount)
+	}
+
+	amount, _ := new(big.Rat).SetString(d.Amount)
+
+	return amount, nil
+}
+
+// Validate returns an error if d has an invalid denomination, or an amount which isn't
+// a non-negative decimal number.
+func (d DecCoin) Validate() error {
+	if err := ValidateDenom(d.Denom); err != nil {
+		return err
+	}
+
+	_, err := d.Rat()
+	return err
+}
+
+// String implements the stringer interface for DecCoin.
+func (d DecCoin) String() string {
+	return d.Amount + d.Denom
+}
+
+// MulGas returns the Coin needed to pay gas units at d's price, rounded up.
+func (d DecCoin) MulGas(gas uint64) (Coin, error) {
+	price, err := d.Rat()
+	if err != nil {
+		return Coin{}, err
+	}
+
+	amount := new(big.Rat).Mul(price, new(big.Rat).SetInt(new(big.Int).SetUint64(gas)))
+
+	// round amount up to the next integer
+	res := new(big.Int).Quo(amount.Num(), amount.Denom())
+	if !amount.IsInt() {
+		res.Add(res, big.NewInt(1))
+	}
+
+	return NewCoin(d.Denom, res), nil
+}
@@ -0,0 +1,260 @@
+package sacco
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoins(t *testing.T) {
+	tests := []struct {
+		name      string
+		coinsStr  string
+		want      Coins
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"single coin",
+			"100ucommercio",
+			Coins{{Denom: "ucommercio", Amount: "100"}},
+			assert.NoError,
+		},
+		{
+			"multiple coins are sorted",
+			"100ucommercio,5uccc",
+			Coins{{Denom: "uccc", Amount: "5"}, {Denom: "ucommercio", Amount: "100"}},
+			assert.NoError,
+		},
+		{
+			"duplicate coins are summed, zero coins removed",
+			"100ucommercio, 5 uccc,50ucommercio,0uatom",
+			Coins{{Denom: "uccc", Amount: "5"}, {Denom: "ucommercio", Amount: "150"}},
+			assert.NoError,
+		},
+		{
+			"amounts bigger than 64 bits",
+			"100000000000000000000000ucommercio",
+			Coins{{Denom: "ucommercio", Amount: "100000000000000000000000"}},
+			assert.NoError,
+		},
+		{
+			"empty string",
+			"",
+			Coins{},
+			assert.NoError,
+		},
+		{
+			"invalid denom",
+			"100UCOMMERCIO",
+			nil,
+			assert.Error,
+		},
+		{
+			"decimal amount",
+			"1.5ucommercio",
+			nil,
+			assert.Error,
+		},
+		{
+			"negative amount",
+			"-5ucommercio",
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCoins(tt.coinsStr)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCoins_arithmetic(t *testing.T) {
+	a, err := ParseCoins("100ucommercio,5uccc")
+	assert.NoError(t, err)
+
+	b, err := ParseCoins("50ucommercio,10uatom")
+	assert.NoError(t, err)
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "10uatom,5uccc,150ucommercio", sum.String())
+	assert.NoError(t, sum.Validate())
+
+	diff, err := sum.Sub(b)
+	assert.NoError(t, err)
+	assert.Equal(t, a, diff)
+
+	diff, err = a.Sub(a)
+	assert.NoError(t, err)
+	assert.True(t, diff.IsZero())
+
+	_, err = a.Sub(b)
+	assert.Error(t, err)
+
+	assert.True(t, sum.IsGTE(a))
+	assert.True(t, sum.IsGTE(b))
+	assert.False(t, a.IsGTE(b))
+	assert.False(t, a.IsZero())
+	assert.True(t, Coins{}.IsZero())
+	assert.Equal(t, big.NewInt(150), sum.AmountOf("ucommercio"))
+	assert.Equal(t, big.NewInt(0), sum.AmountOf("ukrw"))
+}
+
+func TestCoin_arithmetic(t *testing.T) {
+	a := Coin{Denom: "ucommercio", Amount: "100"}
+	b := Coin{Denom: "ucommercio", Amount: "30"}
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, Coin{Denom: "ucommercio", Amount: "130"}, sum)
+
+	diff, err := a.Sub(b)
+	assert.NoError(t, err)
+	assert.Equal(t, Coin{Denom: "ucommercio", Amount: "70"}, diff)
+
+	_, err = b.Sub(a)
+	assert.Error(t, err)
+
+	_, err = a.Add(Coin{Denom: "uccc", Amount: "1"})
+	assert.Error(t, err)
+
+	assert.True(t, a.IsGTE(b))
+	assert.False(t, b.IsGTE(a))
+	assert.False(t, a.IsGTE(Coin{Denom: "uccc", Amount: "1"}))
+	assert.True(t, Coin{Denom: "ucommercio", Amount: "0"}.IsZero())
+}
+
+func TestCoin_invalidAmount(t *testing.T) {
+	valid := Coin{Denom: "ucommercio", Amount: "100"}
+
+	for _, amount := range []string{"abc", "1.5", "-1", "+1", ""} {
+		t.Run(amount, func(t *testing.T) {
+			invalid := Coin{Denom: "ucommercio", Amount: amount}
+
+			assert.Error(t, invalid.Validate())
+
+			_, err := valid.Add(invalid)
+			assert.Error(t, err)
+
+			_, err = invalid.Sub(valid)
+			assert.Error(t, err)
+
+			_, err = Coins{valid}.Add(Coins{invalid})
+			assert.Error(t, err)
+
+			assert.Panics(t, func() { invalid.IsZero() })
+			assert.Panics(t, func() { valid.IsGTE(invalid) })
+			assert.Panics(t, func() { Coins{invalid}.AmountOf("ucommercio") })
+		})
+	}
+}
+
+func TestCoins_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		coins     Coins
+		assertion assert.ErrorAssertionFunc
+	}{
+		{"valid coins", Coins{{Denom: "uccc", Amount: "5"}, {Denom: "ucommercio", Amount: "100"}}, assert.NoError},
+		{"empty coins", Coins{}, assert.NoError},
+		{"unsorted coins", Coins{{Denom: "ucommercio", Amount: "100"}, {Denom: "uccc", Amount: "5"}}, assert.Error},
+		{"duplicate coins", Coins{{Denom: "uccc", Amount: "5"}, {Denom: "uccc", Amount: "100"}}, assert.Error},
+		{"zero coin", Coins{{Denom: "uccc", Amount: "0"}}, assert.Error},
+		{"invalid amount", Coins{{Denom: "uccc", Amount: "five"}}, assert.Error},
+		{"invalid denom", Coins{{Denom: "1uccc", Amount: "5"}}, assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assertion(t, tt.coins.Validate())
+		})
+	}
+}
+
+func TestCoins_JSON(t *testing.T) {
+	coins, err := ParseCoins("100ucommercio,5uccc")
+	assert.NoError(t, err)
+
+	got, err := json.Marshal(coins)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"denom":"uccc","amount":"5"},{"denom":"ucommercio","amount":"100"}]`, string(got))
+}
+
+func TestParseDecCoins(t *testing.T) {
+	tests := []struct {
+		name      string
+		coinsStr  string
+		want      DecCoins
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"single decimal coin",
+			"0.025ucommercio",
+			DecCoins{{Denom: "ucommercio", Amount: "0.025"}},
+			assert.NoError,
+		},
+		{
+			"multiple coins are sorted",
+			"0.025ucommercio,1uccc",
+			DecCoins{{Denom: "uccc", Amount: "1"}, {Denom: "ucommercio", Amount: "0.025"}},
+			assert.NoError,
+		},
+		{
+			"duplicate denoms",
+			"0.025ucommercio,1ucommercio",
+			nil,
+			assert.Error,
+		},
+		{
+			"missing denom",
+			"0.025",
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDecCoins(tt.coinsStr)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecCoin_MulGas(t *testing.T) {
+	price, err := ParseDecCoin("0.025ucommercio")
+	assert.NoError(t, err)
+
+	got, err := price.MulGas(200000)
+	assert.NoError(t, err)
+	assert.Equal(t, Coin{Denom: "ucommercio", Amount: "5000"}, got)
+
+	got, err = price.MulGas(1)
+	assert.NoError(t, err)
+	assert.Equal(t, Coin{Denom: "ucommercio", Amount: "1"}, got)
+}
+
+func TestDecCoin_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{"integer", "1", assert.NoError},
+		{"decimal", "0.025", assert.NoError},
+		{"no integer part", ".5", assert.NoError},
+		{"fraction", "1/3", assert.Error},
+		{"exponent", "1e3", assert.Error},
+		{"negative", "-0.5", assert.Error},
+		{"not a number", "abc", assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assertion(t, DecCoin{Denom: "ucommercio", Amount: tt.amount}.Validate())
+		})
+	}
+}
@@ -2,7 +2,6 @@ package sacco
 
 import (
 	"crypto/sha256"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -10,7 +9,6 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/bech32"
 	"github.com/btcsuite/btcutil/hdkeychain"
-	"github.com/cosmos/go-bip39"
 
 	// TODO: search for a modern, better implementation of ripemd160
 	// nolint:staticcheck
@@ -27,11 +25,17 @@ type derivationComponent struct {
 // deriveFromMnemonic derives an HD keypair and address from a mnemonic, a path and an
 // human-readable part.
 func deriveFromMnemonic(hrp, mnemonic, path string) (key *hdkeychain.ExtendedKey, address string, err error) {
-	if !bip39.IsMnemonicValid(mnemonic) {
-		return nil, "", fmt.Errorf("invalid mnemonic")
+	return deriveFromMnemonicWithPassphrase(hrp, mnemonic, "", path)
+}
+
+// deriveFromMnemonicWithPassphrase derives an HD keypair and address from a mnemonic,
+// a BIP-39 passphrase, a path and an human-readable part.
+func deriveFromMnemonicWithPassphrase(hrp, mnemonic, passphrase, path string) (key *hdkeychain.ExtendedKey, address string, err error) {
+	if !IsMnemonicValid(mnemonic) {
+		return nil, "", ErrInvalidMnemonic
 	}
 
-	seed := bip39.NewSeed(mnemonic, "")
+	seed := newSeed(mnemonic, passphrase)
 	key, err = derivePath(seed, path)
 	if err != nil {
 		return nil, "", err
@@ -154,14 +158,15 @@ func stringToComponents(path string) ([]derivationComponent, error) {
 // hardened returns true whether s is an hardened derivation path
 // component, false otherwise.
 // When hardened returns true, destStr will contain s without the
-// hardened indicator (the "'"), otherwise destStr will be equal to
-// s.
+// hardened indicator (either "'", "h" or "H"), otherwise destStr will
+// be equal to s.
 func hardened(s string) (isHardened bool, destStr string) {
 	if len(s) <= 0 {
 		return false, ""
 	}
 
-	isHardened = s[len(s)-1] == '\''
+	last := s[len(s)-1]
+	isHardened = last == '\'' || last == 'h' || last == 'H'
 	destStr = s
 
 	if isHardened {
@@ -0,0 +1,183 @@
+package sacco
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// DerivationPath is a BIP-32 derivation path, made of the child indexes to derive
+// starting from the master key.
+// Hardened indexes include the hdkeychain.HardenedKeyStart offset.
+type DerivationPath []uint32
+
+// BIP-44 levels of a DerivationPath.
+const (
+	bip44Purpose = iota
+	bip44CoinType
+	bip44Account
+	bip44Change
+	bip44AddressIndex
+	bip44Depth
+)
+
+// NewBIP44DerivationPath returns the BIP-44 DerivationPath
+// m/44'/coinType'/account'/change/index.
+func NewBIP44DerivationPath(coinType, account, change, index uint32) DerivationPath {
+	return DerivationPath{
+		44 + hdkeychain.HardenedKeyStart,
+		coinType + hdkeychain.HardenedKeyStart,
+		account + hdkeychain.HardenedKeyStart,
+		change,
+		index,
+	}
+}
+
+// ParseDerivationPath parses a derivation path string like "m/44'/118'/0'/0/0".
+// Hardened components can be marked with either "'", "h" or "H".
+// ParseDerivationPath is stricter than the path parsing of FromMnemonic: spaces
+// aren't ignored, and indexes must be lower than hdkeychain.HardenedKeyStart.
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	components := strings.Split(path, "/")
+	if len(components) <= 1 {
+		return nil, ErrDerivationPathShort
+	}
+
+	if components[0] != "m" {
+		return nil, ErrDerivationPathFirstCharNotM
+	}
+
+	dp := make(DerivationPath, len(components)-1)
+
+	for i, rawComponent := range components[1:] {
+		isHardened, rawPathNum := hardened(rawComponent)
+
+		pathNum, convErr := strconv.ParseUint(rawPathNum, 10, 32)
+		if convErr != nil || rawPathNum == "" {
+			return nil, ErrComponentNaN(rawPathNum, convErr)
+		}
+
+		if pathNum >= hdkeychain.HardenedKeyStart {
+			return nil, ErrIndexOutOfRange(uint32(pathNum))
+		}
+
+		dp[i] = uint32(pathNum)
+		if isHardened {
+			dp[i] += hdkeychain.HardenedKeyStart
+		}
+	}
+
+	return dp, nil
+}
+
+// String implements the stringer interface for DerivationPath, marking hardened
+// components with "'".
+func (dp DerivationPath) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("m")
+	for _, index := range dp {
+		if index >= hdkeychain.HardenedKeyStart {
+			fmt.Fprintf(&sb, "/%d'", index-hdkeychain.HardenedKeyStart)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "/%d", index)
+	}
+
+	return sb.String()
+}
+
+// ValidateBIP44 returns an error if dp isn't a BIP-44 path of the form
+// m/44'/coinType'/account'/change/index, with change being either 0 or 1.
+func (dp DerivationPath) ValidateBIP44() error {
+	switch {
+	case len(dp) != bip44Depth:
+		return ErrNotBIP44(dp.String(), "path must have 5 levels")
+	case dp[bip44Purpose] != 44+hdkeychain.HardenedKeyStart:
+		return ErrNotBIP44(dp.String(), "purpose must be 44'")
+	case dp[bip44CoinType] < hdkeychain.HardenedKeyStart:
+		return ErrNotBIP44(dp.String(), "coin type must be hardened")
+	case dp[bip44Account] < hdkeychain.HardenedKeyStart:
+		return ErrNotBIP44(dp.String(), "account must be hardened")
+	case dp[bip44Change] > 1:
+		return ErrNotBIP44(dp.String(), "change must be either 0 or 1")
+	case dp[bip44AddressIndex] >= hdkeychain.HardenedKeyStart:
+		return ErrNotBIP44(dp.String(), "address index must not be hardened")
+	}
+
+	return nil
+}
+
+// level returns the index at the given level of dp without the hardened offset,
+// or zero if dp is shorter than that.
+func (dp DerivationPath) level(level int) uint32 {
+	if len(dp) <= level {
+		return 0
+	}
+
+	return dp[level] &^ hdkeychain.HardenedKeyStart
+}
+
+// Purpose returns the BIP-44 purpose of dp.
+func (dp DerivationPath) Purpose() uint32 {
+	return dp.level(bip44Purpose)
+}
+
+// CoinType returns the BIP-44 coin type of dp.
+func (dp DerivationPath) CoinType() uint32 {
+	return dp.level(bip44CoinType)
+}
+
+// Account returns the BIP-44 account of dp.
+func (dp DerivationPath) Account() uint32 {
+	return dp.level(bip44Account)
+}
+
+// Change returns the BIP-44 change level of dp.
+func (dp DerivationPath) Change() uint32 {
+	return dp.level(bip44Change)
+}
+
+// AddressIndex returns the BIP-44 address index of dp.
+func (dp DerivationPath) AddressIndex() uint32 {
+	return dp.level(bip44AddressIndex)
+}
+
+// WithAccount returns a copy of the BIP-44 path dp with its account set to account.
+func (dp DerivationPath) WithAccount(account uint32) (DerivationPath, error) {
+	if account >= hdkeychain.HardenedKeyStart {
+		return nil, ErrIndexOutOfRange(account)
+	}
+
+	return dp.with(bip44Account, account+hdkeychain.HardenedKeyStart)
+}
+
+// WithAddressIndex returns a copy of the BIP-44 path dp with its address index set
+// to index.
+func (dp DerivationPath) WithAddressIndex(index uint32) (DerivationPath, error) {
+	if index >= hdkeychain.HardenedKeyStart {
+		return nil, ErrIndexOutOfRange(index)
+	}
+
+	return dp.with(bip44AddressIndex, index)
+}
+
+// NextIndex returns a copy of the BIP-44 path dp with its address index incremented by one.
+func (dp DerivationPath) NextIndex() (DerivationPath, error) {
+	return dp.WithAddressIndex(dp.AddressIndex() + 1)
+}
+
+// with returns a copy of the BIP-44 path dp, with the index at level set to index.
+func (dp DerivationPath) with(level int, index uint32) (DerivationPath, error) {
+	if err := dp.ValidateBIP44(); err != nil {
+		return nil, err
+	}
+
+	res := append(DerivationPath{}, dp...)
+	res[level] = index
+
+	return res, nil
+}
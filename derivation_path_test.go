@@ -0,0 +1,158 @@
+package sacco
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDerivationPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		want      DerivationPath
+		wantStr   string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"apostrophe hardened notation",
+			"m/44'/118'/0'/0/0",
+			DerivationPath{0x8000002c, 0x80000076, 0x80000000, 0, 0},
+			"m/44'/118'/0'/0/0",
+			assert.NoError,
+		},
+		{
+			"h hardened notation",
+			"m/44h/118H/3h/1/7",
+			DerivationPath{0x8000002c, 0x80000076, 0x80000003, 1, 7},
+			"m/44'/118'/3'/1/7",
+			assert.NoError,
+		},
+		{
+			"non BIP-44 path",
+			"m/0'/1",
+			DerivationPath{0x80000000, 1},
+			"m/0'/1",
+			assert.NoError,
+		},
+		{
+			"spaces",
+			"m / 44' / 118' / 0' / 0 / 0",
+			nil,
+			"",
+			assert.Error,
+		},
+		{
+			"index out of range",
+			"m/44'/118'/0'/0/2147483648",
+			nil,
+			"",
+			assert.Error,
+		},
+		{
+			"missing m",
+			"44'/118'/0'/0/0",
+			nil,
+			"",
+			assert.Error,
+		},
+		{
+			"master only",
+			"m",
+			nil,
+			"",
+			assert.Error,
+		},
+		{
+			"empty component",
+			"m/44'//0'/0/0",
+			nil,
+			"",
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDerivationPath(tt.path)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+
+			if got != nil {
+				assert.Equal(t, tt.wantStr, got.String())
+			}
+		})
+	}
+}
+
+func TestDerivationPath_ValidateBIP44(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{"cosmos path", CosmosDerivationPath, assert.NoError},
+		{"change address", "m/44'/118'/2'/1/5", assert.NoError},
+		{"too short", "m/44'/118'/0'/0", assert.Error},
+		{"too long", "m/44'/118'/0'/0/0/0", assert.Error},
+		{"wrong purpose", "m/49'/118'/0'/0/0", assert.Error},
+		{"non-hardened purpose", "m/44/118'/0'/0/0", assert.Error},
+		{"non-hardened coin type", "m/44'/118/0'/0/0", assert.Error},
+		{"non-hardened account", "m/44'/118'/0/0/0", assert.Error},
+		{"invalid change", "m/44'/118'/0'/2/0", assert.Error},
+		{"hardened change", "m/44'/118'/0'/0'/0", assert.Error},
+		{"hardened index", "m/44'/118'/0'/0/0'", assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp, err := ParseDerivationPath(tt.path)
+			assert.NoError(t, err)
+
+			tt.assertion(t, dp.ValidateBIP44())
+		})
+	}
+}
+
+func TestDerivationPath_accessors(t *testing.T) {
+	dp := NewBIP44DerivationPath(118, 3, 1, 7)
+	assert.Equal(t, "m/44'/118'/3'/1/7", dp.String())
+	assert.NoError(t, dp.ValidateBIP44())
+
+	assert.Equal(t, uint32(44), dp.Purpose())
+	assert.Equal(t, uint32(118), dp.CoinType())
+	assert.Equal(t, uint32(3), dp.Account())
+	assert.Equal(t, uint32(1), dp.Change())
+	assert.Equal(t, uint32(7), dp.AddressIndex())
+
+	next, err := dp.NextIndex()
+	assert.NoError(t, err)
+	assert.Equal(t, "m/44'/118'/3'/1/8", next.String())
+
+	// dp is left untouched
+	assert.Equal(t, "m/44'/118'/3'/1/7", dp.String())
+
+	other, err := dp.WithAccount(5)
+	assert.NoError(t, err)
+	assert.Equal(t, "m/44'/118'/5'/1/7", other.String())
+
+	other, err = dp.WithAddressIndex(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "m/44'/118'/3'/1/0", other.String())
+
+	_, err = dp.WithAccount(0x80000000)
+	assert.Error(t, err)
+
+	_, err = NewBIP44DerivationPath(118, 0, 0, 0x7fffffff).NextIndex()
+	assert.Error(t, err)
+
+	_, err = DerivationPath{0x80000000}.NextIndex()
+	assert.Error(t, err)
+}
+
+func TestFromMnemonic_hardenedNotation(t *testing.T) {
+	want, err := FromMnemonic("did:com:", testMnemonic, "m/44'/118'/0'/0/0")
+	assert.NoError(t, err)
+
+	got, err := FromMnemonic("did:com:", testMnemonic, "m/44h/118h/0h/0/0")
+	assert.NoError(t, err)
+	assert.Equal(t, want.Address, got.Address)
+}
@@ -179,6 +179,33 @@ func Test_stringToComponents(t *testing.T) {
 			},
 			assert.NoError,
 		},
+		{
+			"a derivation path with an index out of the BIP-32 range",
+			"m/44'/0'/0'/0/2147483648",
+			[]derivationComponent{
+				{
+					Path:     44,
+					Hardened: true,
+				},
+				{
+					Path:     0,
+					Hardened: true,
+				},
+				{
+					Path:     0,
+					Hardened: true,
+				},
+				{
+					Path:     0,
+					Hardened: false,
+				},
+				{
+					Path:     2147483648,
+					Hardened: false,
+				},
+			},
+			assert.NoError,
+		},
 		{
 			"derivation path which doesn't begin with \"m\"",
 			"/44'/0'/0'/0/0",
@@ -0,0 +1,320 @@
+package sacco
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// SignMode is the way transactions are encoded and signed for a chain.
+type SignMode int
+
+const (
+	// SignModeLegacyAmino signs the amino JSON StdSignDoc of transactions, and broadcasts
+	// them as amino JSON to /txs, as Cosmos SDK chains up to v0.39 expect.
+	SignModeLegacyAmino SignMode = iota
+
+	// SignModeDirect signs the protobuf SignDoc of transactions, and broadcasts them as
+	// protobuf TxRaw to /cosmos/tx/v1beta1/txs, as Cosmos SDK v0.40+ chains expect.
+	SignModeDirect
+)
+
+const (
+	// secp256k1ProtoTypeURL is the type URL of secp256k1 public keys packed into a
+	// google.protobuf.Any.
+	secp256k1ProtoTypeURL = "/cosmos.crypto.secp256k1.PubKey"
+
+	// protoSignModeDirect is the cosmos.tx.signing.v1beta1.SignMode value of SIGN_MODE_DIRECT.
+	protoSignModeDirect = 1
+)
+
+// TxRaw is a protobuf transaction signed with SignModeDirect, as broadcast to Cosmos SDK
+// v0.40+ chains.
+type TxRaw struct {
+	// BodyBytes is the protobuf encoding of the transaction TxBody.
+	BodyBytes []byte
+
+	// AuthInfoBytes is the protobuf encoding of the transaction AuthInfo.
+	AuthInfoBytes []byte
+
+	// Signatures holds a signature for each signer listed in AuthInfoBytes.
+	Signatures [][]byte
+}
+
+// Marshal returns the protobuf encoding of tx.
+func (tx TxRaw) Marshal() []byte {
+	var b protoBuffer
+	b.BytesField(1, tx.BodyBytes)
+	b.BytesField(2, tx.AuthInfoBytes)
+
+	for _, sig := range tx.Signatures {
+		b.Message(3, sig)
+	}
+
+	return b.Bytes()
+}
+
+// protoTxBody returns the protobuf encoding of the TxBody holding tx messages and memo.
+// Each message must be of a type registered with RegisterProtoMsg.
+func protoTxBody(tx TransactionPayload) ([]byte, error) {
+	var b protoBuffer
+
+	for _, raw := range tx.Message {
+		msg, err := unmarshalProtoMsg(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		msgBytes, err := msg.MarshalProto()
+		if err != nil {
+			return nil, err
+		}
+
+		b.Message(1, protoAny(msg.ProtoTypeURL(), msgBytes))
+	}
+
+	b.String(2, tx.Memo)
+
+	return b.Bytes(), nil
+}
+
+// protoAuthInfo returns the protobuf encoding of the AuthInfo of a transaction paying fee,
+// signed with SignModeDirect by the owner of the secp256k1 public key pubKey at sequence.
+func protoAuthInfo(pubKey []byte, sequence uint64, fee Fee) ([]byte, error) {
+	gas, err := strconv.ParseUint(fee.Gas, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidGas(fee.Gas)
+	}
+
+	var pkb protoBuffer
+	pkb.BytesField(1, pubKey)
+
+	var single protoBuffer
+	single.Uint64(1, protoSignModeDirect)
+
+	var modeInfo protoBuffer
+	modeInfo.Message(1, single.Bytes())
+
+	var signerInfo protoBuffer
+	signerInfo.Message(1, protoAny(secp256k1ProtoTypeURL, pkb.Bytes()))
+	signerInfo.Message(2, modeInfo.Bytes())
+	signerInfo.Uint64(3, sequence)
+
+	var feeb protoBuffer
+	feeb.Coins(1, fee.Amount)
+	feeb.Uint64(2, gas)
+
+	var b protoBuffer
+	b.Message(1, signerInfo.Bytes())
+	b.Message(2, feeb.Bytes())
+
+	return b.Bytes(), nil
+}
+
+// directSignBytes returns the protobuf encoding of the SignDoc signed with SignModeDirect.
+func directSignBytes(bodyBytes, authInfoBytes []byte, chainID string, accountNumber uint64) []byte {
+	var b protoBuffer
+	b.BytesField(1, bodyBytes)
+	b.BytesField(2, authInfoBytes)
+	b.String(3, chainID)
+	b.Uint64(4, accountNumber)
+
+	return b.Bytes()
+}
+
+// SignDirect encodes tx as a protobuf transaction and signs it with SignModeDirect, given
+// chainID, accountNumber and sequence, with w's private key.
+// Each message of tx must be of a type registered with RegisterProtoMsg, and tx Fee gas
+// must be set.
+func (w Wallet) SignDirect(tx TransactionPayload, chainID string, accountNumber, sequence uint64) (TxRaw, error) {
+	if w.IsWatchOnly() {
+		return TxRaw{}, ErrWatchOnly
+	}
+
+	pubKey, err := w.publicKey.ECPubKey()
+	if err != nil {
+		return TxRaw{}, err
+	}
+
+	bodyBytes, err := protoTxBody(tx)
+	if err != nil {
+		return TxRaw{}, err
+	}
+
+	authInfoBytes, err := protoAuthInfo(pubKey.SerializeCompressed(), sequence, tx.Fee)
+	if err != nil {
+		return TxRaw{}, err
+	}
+
+	sig, err := w.signRaw(directSignBytes(bodyBytes, authInfoBytes, chainID, accountNumber))
+	if err != nil {
+		return TxRaw{}, err
+	}
+
+	return TxRaw{
+		BodyBytes:     bodyBytes,
+		AuthInfoBytes: authInfoBytes,
+		Signatures:    [][]byte{sig},
+	}, nil
+}
+
+// protoBroadcastModes maps each TxMode to its cosmos.tx.v1beta1.BroadcastMode name.
+var protoBroadcastModes = map[TxMode]string{
+	ModeSync:  "BROADCAST_MODE_SYNC",
+	ModeAsync: "BROADCAST_MODE_ASYNC",
+	ModeBlock: "BROADCAST_MODE_BLOCK",
+}
+
+// BroadcastTxRaw broadcasts a protobuf transaction to the /cosmos/tx/v1beta1/txs endpoint
+// of the Cosmos SDK v0.40+ LCD c is connected to, and returns the whole TxResponse the LCD
+// replied with.
+// If the transaction has been rejected by the chain, both the TxResponse and an error
+// are returned.
+func (c *Client) BroadcastTxRaw(ctx context.Context, tx TxRaw, txMode TxMode) (TxResponse, error) {
+	mode, ok := protoBroadcastModes[txMode]
+	if !ok {
+		return TxResponse{}, ErrUnknownTxMode(txMode)
+	}
+
+	requestBody, err := json.Marshal(struct {
+		TxBytes string `json:"tx_bytes"`
+		Mode    string `json:"mode"`
+	}{
+		TxBytes: base64.StdEncoding.EncodeToString(tx.Marshal()),
+		Mode:    mode,
+	})
+	if err != nil {
+		return TxResponse{}, err
+	}
+
+	var txr TxResponse
+
+	err = retry(ctx, c.BroadcastRetry, IsRetryableBroadcastError, func() error {
+		txr, err = c.broadcastTxRaw(ctx, requestBody)
+		return err
+	})
+
+	return txr, err
+}
+
+// broadcastTxRaw posts the BroadcastTxRequest requestBody to the LCD, and returns the
+// TxResponse it replied with.
+func (c *Client) broadcastTxRaw(ctx context.Context, requestBody []byte) (TxResponse, error) {
+	resp, err := c.post(ctx, "/cosmos/tx/v1beta1/txs", requestBody)
+	if err != nil {
+		return TxResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return TxResponse{}, fmt.Errorf("error while processing tx send request: %w", newHTTPError(resp))
+	}
+
+	var broadcastResp struct {
+		TxResponse TxResponse `json:"tx_response"`
+	}
+
+	jdec := json.NewDecoder(resp.Body)
+
+	if err := jdec.Decode(&broadcastResp); err != nil {
+		return TxResponse{}, fmt.Errorf("could not deserialize cosmos txresponse from lcd: %w", err)
+	}
+
+	txr := broadcastResp.TxResponse
+
+	return txr, txResponseError(txr)
+}
+
+// simulateDirect asks the /cosmos/tx/v1beta1/simulate endpoint of a Cosmos SDK v0.40+ LCD
+// to simulate tx as if it was signed by signer at sequence, and returns the gas it used.
+// Signatures aren't verified during simulations, hence tx is sent with an empty one.
+func (c *Client) simulateDirect(ctx context.Context, tx TransactionPayload, signer *Wallet, sequence uint64) (uint64, error) {
+	pubKey, err := signer.publicKey.ECPubKey()
+	if err != nil {
+		return 0, err
+	}
+
+	bodyBytes, err := protoTxBody(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	authInfoBytes, err := protoAuthInfo(pubKey.SerializeCompressed(), sequence, tx.Fee)
+	if err != nil {
+		return 0, err
+	}
+
+	txRaw := TxRaw{
+		BodyBytes:     bodyBytes,
+		AuthInfoBytes: authInfoBytes,
+		Signatures:    [][]byte{{}},
+	}
+
+	requestBody, err := json.Marshal(struct {
+		TxBytes string `json:"tx_bytes"`
+	}{
+		TxBytes: base64.StdEncoding.EncodeToString(txRaw.Marshal()),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.post(ctx, "/cosmos/tx/v1beta1/simulate", requestBody)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("error while processing simulation request: %w", newHTTPError(resp))
+	}
+
+	var simulateResp struct {
+		GasInfo struct {
+			GasUsed uint64 `json:"gas_used,string"`
+		} `json:"gas_info"`
+	}
+
+	jdec := json.NewDecoder(resp.Body)
+	if err := jdec.Decode(&simulateResp); err != nil {
+		return 0, fmt.Errorf("could not deserialize gas estimate from lcd: %w", err)
+	}
+
+	return simulateResp.GasInfo.GasUsed, nil
+}
+
+// directNodeInfo returns the node informations exposed by the
+// /cosmos/base/tendermint/v1beta1/node_info endpoint of a Cosmos SDK v0.40+ LCD.
+func (c *Client) directNodeInfo(ctx context.Context) (NodeInfo, error) {
+	resp, err := c.get(ctx, "/cosmos/base/tendermint/v1beta1/node_info")
+	if err != nil {
+		return NodeInfo{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return NodeInfo{}, fmt.Errorf("error during node info query: %w", newHTTPError(resp))
+	}
+
+	var nodeInfoResp struct {
+		NodeInfo json.RawMessage `json:"default_node_info"`
+	}
+
+	jdec := json.NewDecoder(resp.Body)
+	if err := jdec.Decode(&nodeInfoResp); err != nil {
+		return NodeInfo{}, err
+	}
+
+	var nodeInfo NodeInfo
+	if err := json.Unmarshal(nodeInfoResp.NodeInfo, &nodeInfo.Info); err != nil {
+		return NodeInfo{}, err
+	}
+
+	return nodeInfo, nil
+}
@@ -0,0 +1,298 @@
+package sacco
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func testDirectTx(t *testing.T) TransactionPayload {
+	tx := TransactionPayload{
+		Fee:  Fee{Amount: []Coin{{Denom: "uatom", Amount: "5000"}}, Gas: "200000"},
+		Memo: "sacco",
+	}
+	require.NoError(t, tx.AddMsgs(MsgSend{
+		FromAddress: testAccAddress,
+		ToAddress:   testAccAddress,
+		Amount:      Coins{{Denom: "uatom", Amount: "10"}},
+	}))
+
+	return tx
+}
+
+func TestWallet_SignDirect(t *testing.T) {
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := testDirectTx(t)
+
+	got, err := w.SignDirect(tx, "cosmoshub-4", 11, 3)
+	require.NoError(t, err)
+
+	// TxBody: MsgSend packed into an Any, and the memo
+	assert.Equal(t,
+		"0a8b010a1c2f636f736d6f732e62616e6b2e763162657461312e4d736753656e64126b"+
+			"0a2d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b7739122d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b77391a0b0a057561746f6d12023130"+
+			"1205736163636f",
+		hex.EncodeToString(got.BodyBytes),
+	)
+
+	// AuthInfo: w's public key, SIGN_MODE_DIRECT, sequence 3 and the fee
+	assert.Equal(t,
+		"0a500a460a1f2f636f736d6f732e63727970746f2e736563703235366b312e5075624b657912230a2103a584852d63476ab050b0ae9de47b9a220c9f383f945b9bbb721a3dbd0ae1355012040a020801180312130a0d0a057561746f6d12043530303010c09a0c",
+		hex.EncodeToString(got.AuthInfoBytes),
+	)
+
+	require.Len(t, got.Signatures, 1)
+
+	pubKey, err := w.publicKey.ECPubKey()
+	require.NoError(t, err)
+
+	var tmPubKey secp256k1.PubKeySecp256k1
+	copy(tmPubKey[:], pubKey.SerializeCompressed())
+
+	signDoc := directSignBytes(got.BodyBytes, got.AuthInfoBytes, "cosmoshub-4", 11)
+	assert.True(t, tmPubKey.VerifyBytes(signDoc, got.Signatures[0]))
+	assert.False(t, tmPubKey.VerifyBytes(directSignBytes(got.BodyBytes, got.AuthInfoBytes, "cosmoshub-4", 12), got.Signatures[0]))
+}
+
+func TestWallet_SignDirect_Errors(t *testing.T) {
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	watchOnly, err := FromXPub("cosmos", w.PublicKey)
+	require.NoError(t, err)
+
+	unsupported := testDirectTx(t)
+	require.NoError(t, unsupported.AddMsgs(MsgInviteUser{Recipient: testAccAddress, Sender: testAccAddress}))
+
+	invalidGas := testDirectTx(t)
+	invalidGas.Fee.Gas = "auto"
+
+	tests := []struct {
+		name string
+		w    *Wallet
+		tx   TransactionPayload
+	}{
+		{"watch-only wallet", watchOnly, testDirectTx(t)},
+		{"message without protobuf encoding", w, unsupported},
+		{"invalid gas", w, invalidGas},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.w.SignDirect(tt.tx, "cosmoshub-4", 11, 3)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTxRaw_Marshal(t *testing.T) {
+	tx := TxRaw{
+		BodyBytes:     []byte{0x01},
+		AuthInfoBytes: []byte{0x02, 0x03},
+		Signatures:    [][]byte{{0x04}, {}},
+	}
+
+	assert.Equal(t, "0a0101120202031a01041a00", hex.EncodeToString(tx.Marshal()))
+}
+
+func TestClient_BroadcastTxRaw(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:1317"
+
+	txRaw := TxRaw{
+		BodyBytes:     []byte("body"),
+		AuthInfoBytes: []byte("auth info"),
+		Signatures:    [][]byte{[]byte("signature")},
+	}
+
+	tests := []struct {
+		name       string
+		txMode     TxMode
+		wantMode   string
+		jsonResp   string
+		statusResp int
+		want       TxResponse
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"transaction accepted",
+			ModeSync,
+			"BROADCAST_MODE_SYNC",
+			`{"tx_response":{"height":"0","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","codespace":"","code":0,"data":"","raw_log":"[]","info":"","gas_wanted":"0","gas_used":"0","tx":null,"timestamp":""}}`,
+			http.StatusOK,
+			TxResponse{
+				Height:    "0",
+				TxHash:    "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				RawLog:    "[]",
+				GasWanted: "0",
+				GasUsed:   "0",
+				RawTx:     json.RawMessage("null"),
+			},
+			assert.NoError,
+		},
+		{
+			"transaction rejected by the chain",
+			ModeBlock,
+			"BROADCAST_MODE_BLOCK",
+			`{"tx_response":{"height":"1590","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","codespace":"sdk","code":5,"raw_log":"insufficient funds","gas_wanted":"200000","gas_used":"31231"}}`,
+			http.StatusOK,
+			TxResponse{
+				Height:    "1590",
+				TxHash:    "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				Codespace: "sdk",
+				Code:      5,
+				RawLog:    "insufficient funds",
+				GasWanted: "200000",
+				GasUsed:   "31231",
+			},
+			assert.Error,
+		},
+		{
+			"unsuccessful request",
+			ModeAsync,
+			"BROADCAST_MODE_ASYNC",
+			`{"code":3,"message":"tx parse error","details":[]}`,
+			http.StatusBadRequest,
+			TxResponse{},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/cosmos/tx/v1beta1/txs",
+				func(req *http.Request) (*http.Response, error) {
+					var body struct {
+						TxBytes string `json:"tx_bytes"`
+						Mode    string `json:"mode"`
+					}
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+					assert.Equal(t, tt.wantMode, body.Mode)
+					assert.Equal(t, base64.StdEncoding.EncodeToString(txRaw.Marshal()), body.TxBytes)
+
+					return httpmock.NewStringResponse(tt.statusResp, tt.jsonResp), nil
+				})
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			got, err := c.BroadcastTxRaw(context.Background(), txRaw, tt.txMode)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_AccountData_SignModeDirect(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:1317"
+
+	tests := []struct {
+		name       string
+		jsonResp   string
+		statusResp int
+		want       AccountData
+		wantErr    error
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"existing account",
+			`{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"` + testAccAddress + `","pub_key":null,"account_number":"11","sequence":"3"}}`,
+			http.StatusOK,
+			AccountData{
+				Result: AccountDataResult{
+					Value: AccountDataValue{
+						Address:       testAccAddress,
+						AccountNumber: 11,
+						Sequence:      3,
+					},
+				},
+			},
+			nil,
+			assert.NoError,
+		},
+		{
+			"unknown account",
+			`{"code":5,"message":"rpc error: code = NotFound desc = account ` + testAccAddress + ` not found: key not found","details":[]}`,
+			http.StatusNotFound,
+			AccountData{},
+			ErrAccountNotOnline,
+			assert.Error,
+		},
+		{
+			"unsuccessful request",
+			`{"code":3,"message":"decoding bech32 failed","details":[]}`,
+			http.StatusBadRequest,
+			AccountData{},
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/auth/v1beta1/accounts/"+testAccAddress,
+				httpmock.NewStringResponder(tt.statusResp, tt.jsonResp))
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			c.SignMode = SignModeDirect
+
+			got, err := c.AccountData(context.Background(), testAccAddress)
+
+			tt.assertion(t, err)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWallet_SignAndBroadcastWithResponse_SignModeDirect(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:1317"
+
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := testDirectTx(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/base/tendermint/v1beta1/node_info",
+		httpmock.NewStringResponder(http.StatusOK, `{"default_node_info":{"network":"cosmoshub-4","moniker":"node"},"application_version":{"name":"gaia"}}`))
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/auth/v1beta1/accounts/"+w.Address,
+		httpmock.NewStringResponder(http.StatusOK, `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"`+w.Address+`","pub_key":null,"account_number":"11","sequence":"3"}}`))
+
+	want, err := w.SignDirect(tx, "cosmoshub-4", 11, 3)
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/cosmos/tx/v1beta1/txs",
+		func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				TxBytes string `json:"tx_bytes"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, base64.StdEncoding.EncodeToString(want.Marshal()), body.TxBytes)
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"tx_response":{"height":"0","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","code":0}}`), nil
+		})
+
+	c := NewClient(mockHTTPEndpoint, nil, nil)
+	c.SignMode = SignModeDirect
+
+	got, err := w.SignAndBroadcastWithResponse(context.Background(), c, tx, ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656", got.TxHash)
+}
@@ -0,0 +1,64 @@
+package sacco
+
+import (
+	"context"
+	"errors"
+)
+
+// DefaultGapLimit is the number of consecutive unused addresses after which account
+// discovery stops, as suggested by BIP-44.
+const DefaultGapLimit = 20
+
+// Discover walks the addresses of account, starting from index 0, and returns the
+// Wallets which have been used on chain, according to the node client is connected to.
+// Discovery stops after gapLimit consecutive unused addresses; if gapLimit is zero,
+// DefaultGapLimit is used.
+// Every request is bound to ctx.
+func (hd *HDWallet) Discover(ctx context.Context, client Backend, account, gapLimit uint32) ([]*Wallet, error) {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+
+	used := []*Wallet{}
+	gap := uint32(0)
+
+	for index := uint32(0); gap < gapLimit; index++ {
+		w, err := hd.Wallet(account, index)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = client.AccountData(ctx, w.Address)
+		switch {
+		case errors.Is(err, ErrAccountNotOnline):
+			gap++
+		case err != nil:
+			return nil, err
+		default:
+			used = append(used, w)
+			gap = 0
+		}
+	}
+
+	return used, nil
+}
+
+// DiscoverAccounts runs Discover on each account, starting from account 0, and stops
+// at the first account with no used addresses, as described by BIP-44.
+// It returns the used Wallets of every discovered account.
+func (hd *HDWallet) DiscoverAccounts(ctx context.Context, client Backend, gapLimit uint32) ([]*Wallet, error) {
+	used := []*Wallet{}
+
+	for account := uint32(0); ; account++ {
+		wallets, err := hd.Discover(ctx, client, account, gapLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(wallets) == 0 {
+			return used, nil
+		}
+
+		used = append(used, wallets...)
+	}
+}
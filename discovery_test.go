@@ -0,0 +1,96 @@
+package sacco
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHDWallet_Discover(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	hd, err := NewHDWallet("did:com:", testMnemonic, "")
+	assert.NoError(t, err)
+
+	// used addresses: account 0 indexes 0, 2 and 6, account 1 index 0
+	var used []*Wallet
+	for _, ai := range [][2]uint32{{0, 0}, {0, 2}, {0, 6}, {1, 0}} {
+		w, err := hd.Wallet(ai[0], ai[1])
+		assert.NoError(t, err)
+
+		used = append(used, w)
+	}
+
+	tests := []struct {
+		name      string
+		account   uint32
+		gapLimit  uint32
+		want      []*Wallet
+		wantCalls int
+	}{
+		{"gap limit reached before the last used address", 0, 3, used[:2], 6},
+		{"gap limit past the last used address", 0, 4, used[:3], 11},
+		{"default gap limit", 0, 0, used[:3], 27},
+		{"other account", 1, 2, used[3:], 3},
+		{"unused account", 2, 2, []*Wallet{}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", `=~^`+mockHTTPEndpoint+`/auth/accounts/`,
+				httpmock.NewStringResponder(http.StatusOK, `{"height":"1809","result":{"type":"cosmos-sdk/Account","value":{"address":"","coins":[],"public_key":null,"account_number":0,"sequence":0}}}`))
+			for _, w := range used {
+				httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+w.Address,
+					httpmock.NewStringResponder(http.StatusOK, `{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"`+w.Address+`","account_number":11,"sequence":0}}}`))
+			}
+
+			got, err := hd.Discover(context.Background(), NewClient(mockHTTPEndpoint, nil, nil), tt.account, tt.gapLimit)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCalls, httpmock.GetTotalCallCount())
+		})
+	}
+}
+
+func TestHDWallet_DiscoverAccounts(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	hd, err := NewHDWallet("did:com:", testMnemonic, "")
+	assert.NoError(t, err)
+
+	var used []*Wallet
+	for _, ai := range [][2]uint32{{0, 0}, {0, 1}, {1, 0}, {3, 0}} {
+		w, err := hd.Wallet(ai[0], ai[1])
+		assert.NoError(t, err)
+
+		used = append(used, w)
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `=~^`+mockHTTPEndpoint+`/auth/accounts/`,
+		httpmock.NewStringResponder(http.StatusOK, `{"height":"1809","result":{"type":"cosmos-sdk/Account","value":{"address":"","coins":[],"public_key":null,"account_number":0,"sequence":0}}}`))
+	for _, w := range used {
+		httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+w.Address,
+			httpmock.NewStringResponder(http.StatusOK, `{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"`+w.Address+`","account_number":11,"sequence":0}}}`))
+	}
+
+	client := NewClient(mockHTTPEndpoint, nil, nil)
+
+	// account 2 is unused, so account 3 is never discovered
+	got, err := hd.DiscoverAccounts(context.Background(), client, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, used[:3], got)
+
+	httpmock.RegisterResponder("GET", `=~^`+mockHTTPEndpoint+`/auth/accounts/`,
+		httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"internal error"}`))
+
+	_, err = hd.DiscoverAccounts(context.Background(), client, 2)
+	assert.Error(t, err)
+}
@@ -1,6 +1,9 @@
 package sacco
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrDerivationPathShort represents an error that happens when the derivation path
 // is too short.
@@ -31,3 +34,378 @@ var ErrCouldNotNeuter = func(err error) error {
 var ErrCouldNotBech32 = func(err error) error {
 	return fmt.Errorf("could not convert public key to bech32: %w", err)
 }
+
+// ErrInvalidMnemonic happens when a mnemonic isn't a valid BIP-39 mnemonic in any
+// of the supported languages.
+var ErrInvalidMnemonic = fmt.Errorf("invalid mnemonic")
+
+// ErrAmbiguousMnemonic happens when a mnemonic is valid in more than one of the
+// supported languages, because it's only made of words shared by their wordlists.
+var ErrAmbiguousMnemonic = func(langs []Language) error {
+	names := make([]string, len(langs))
+	for i, l := range langs {
+		names[i] = l.String()
+	}
+
+	return fmt.Errorf("ambiguous mnemonic, valid in languages %s", strings.Join(names, ", "))
+}
+
+// ErrMnemonicLength happens when a mnemonic is made of an unsupported number of words.
+var ErrMnemonicLength = func(words int) error {
+	return fmt.Errorf("invalid mnemonic length %d, must be one of 12, 15, 18, 21 or 24 words", words)
+}
+
+// ErrUnknownLanguage happens when a BIP-39 wordlist language isn't supported.
+var ErrUnknownLanguage = func(lang Language) error {
+	return fmt.Errorf("unknown mnemonic language \"%s\"", lang)
+}
+
+// ErrAccountNotOnline happens when the LCD doesn't know about an account, because it
+// has never received any token.
+var ErrAccountNotOnline = fmt.Errorf("account is not online")
+
+// ErrTxNotFound happens when the LCD doesn't know about a transaction, because it hasn't
+// been included in a block yet or because it doesn't exist at all.
+var ErrTxNotFound = fmt.Errorf("transaction not found")
+
+// HTTPError represents an unexpected HTTP response sent by an LCD.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Body is the raw body of the response.
+	Body string
+
+	// Message is the error message the LCD encoded in Body, if any.
+	Message string
+}
+
+// Error implements the error interface for HTTPError.
+func (e *HTTPError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("lcd replied with status %d: %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("lcd replied with status %d: %s", e.StatusCode, e.Body)
+}
+
+// TxError represents a transaction which has been rejected by the chain.
+// Use errors.Is to compare a TxError with the SDKError sentinels, like
+// ErrInsufficientFunds or ErrOutOfGas.
+type TxError struct {
+	Code      uint32
+	Codespace string
+	RawLog    string
+	TxHash    string
+}
+
+// Error implements the error interface for TxError.
+func (e *TxError) Error() string {
+	return fmt.Sprintf("codespace %s: %s, code %d", e.Codespace, e.RawLog, e.Code)
+}
+
+// Is returns true whether target is an *SDKError with the same codespace and
+// code as e.
+// ErrInvalidSequence also matches the sequence mismatches reported by SDK v0.40+
+// as ErrWrongSequence.
+func (e *TxError) Is(target error) bool {
+	sdkErr, ok := target.(*SDKError)
+	if !ok {
+		return false
+	}
+
+	if e.is(sdkErr) {
+		return true
+	}
+
+	if sdkErr != ErrInvalidSequence {
+		return false
+	}
+
+	// SDK v0.40+ reports sequence mismatches with a dedicated code, while
+	// older versions report them as a generic signature verification failure
+	return e.is(ErrWrongSequence) ||
+		(e.is(ErrUnauthorized) && strings.Contains(e.RawLog, "sequence"))
+}
+
+// is returns true whether e has the same codespace and code as sdkErr.
+func (e *TxError) is(sdkErr *SDKError) bool {
+	return e.Codespace == sdkErr.Codespace && e.Code == sdkErr.Code
+}
+
+// SDKError is an error registered by the Cosmos SDK, identified by its codespace and code.
+type SDKError struct {
+	Codespace   string
+	Code        uint32
+	Description string
+}
+
+// Error implements the error interface for SDKError.
+func (e *SDKError) Error() string {
+	return e.Description
+}
+
+// Errors registered by the Cosmos SDK in the "sdk" codespace.
+var (
+	ErrTxDecode          = &SDKError{"sdk", 2, "tx parse error"}
+	ErrInvalidSequence   = &SDKError{"sdk", 3, "invalid sequence"}
+	ErrUnauthorized      = &SDKError{"sdk", 4, "unauthorized"}
+	ErrInsufficientFunds = &SDKError{"sdk", 5, "insufficient funds"}
+	ErrUnknownRequest    = &SDKError{"sdk", 6, "unknown request"}
+	ErrInvalidAddress    = &SDKError{"sdk", 7, "invalid address"}
+	ErrInvalidPubKey     = &SDKError{"sdk", 8, "invalid pubkey"}
+	ErrUnknownAddress    = &SDKError{"sdk", 9, "unknown address"}
+	ErrInvalidCoins      = &SDKError{"sdk", 10, "invalid coins"}
+	ErrOutOfGas          = &SDKError{"sdk", 11, "out of gas"}
+	ErrMemoTooLarge      = &SDKError{"sdk", 12, "memo too large"}
+	ErrInsufficientFee   = &SDKError{"sdk", 13, "insufficient fee"}
+	ErrTooManySignatures = &SDKError{"sdk", 14, "maximum number of signatures exceeded"}
+	ErrNoSignatures      = &SDKError{"sdk", 15, "no signatures supplied"}
+	ErrInvalidRequest    = &SDKError{"sdk", 18, "invalid request"}
+	ErrTxInMempoolCache  = &SDKError{"sdk", 19, "tx already in mempool"}
+	ErrMempoolIsFull     = &SDKError{"sdk", 20, "mempool is full"}
+	ErrTxTooLarge        = &SDKError{"sdk", 21, "tx too large"}
+	ErrWrongSequence     = &SDKError{"sdk", 32, "account sequence mismatch"}
+)
+
+// ErrInvalidGasPrice happens when a gas price isn't formatted as a decimal amount
+// followed by a denomination, like "0.025ucommercio".
+var ErrInvalidGasPrice = func(gasPrice string) error {
+	return fmt.Errorf("invalid gas price \"%s\"", gasPrice)
+}
+
+// ErrInvalidGasAdjustment happens when a gas adjustment is negative.
+var ErrInvalidGasAdjustment = func(adjustment float64) error {
+	return fmt.Errorf("invalid gas adjustment %f", adjustment)
+}
+
+// ErrUnsupportedSimulationMsg happens when asking a legacy amino LCD to simulate a
+// message which has no module REST route.
+var ErrUnsupportedSimulationMsg = func(msgType string) error {
+	return fmt.Errorf("message type %s can't be simulated by a legacy amino lcd, use an RPCClient", msgType)
+}
+
+// ErrInvalidDenom happens when a coin denomination doesn't match the Cosmos SDK
+// denomination format.
+var ErrInvalidDenom = func(denom string) error {
+	return fmt.Errorf("invalid denom \"%s\"", denom)
+}
+
+// ErrInvalidCoin happens when a coin string can't be parsed.
+var ErrInvalidCoin = func(coin string) error {
+	return fmt.Errorf("invalid coin expression \"%s\"", coin)
+}
+
+// ErrInvalidCoinAmount happens when a coin amount isn't a valid non-negative number.
+var ErrInvalidCoinAmount = func(amount string) error {
+	return fmt.Errorf("invalid coin amount \"%s\"", amount)
+}
+
+// ErrDenomMismatch happens when an operation is performed on coins with different
+// denominations.
+var ErrDenomMismatch = func(a, b string) error {
+	return fmt.Errorf("coin denominations mismatch: %s, %s", a, b)
+}
+
+// ErrNegativeCoins happens when subtracting b from a would yield a negative amount.
+var ErrNegativeCoins = func(a, b string) error {
+	return fmt.Errorf("subtracting %s from %s yields a negative amount", b, a)
+}
+
+// ErrCoinsNotSorted happens when a set of coins isn't sorted by denomination, or
+// contains duplicate denominations.
+var ErrCoinsNotSorted = func(coins string) error {
+	return fmt.Errorf("coins %s are not sorted or contain duplicates", coins)
+}
+
+// ErrDuplicateDenom happens when a set of coins contains the same denomination twice.
+var ErrDuplicateDenom = func(denom string) error {
+	return fmt.Errorf("duplicate denom %s", denom)
+}
+
+// ErrInvalidUnsignedTx happens when an UnsignedTx lacks some of the informations needed
+// to sign it, or holds malformed ones.
+var ErrInvalidUnsignedTx = func(reason string) error {
+	return fmt.Errorf("invalid unsigned transaction: %s", reason)
+}
+
+// ErrSignerMismatch happens when a Wallet is asked to sign a transaction prepared
+// for another address.
+var ErrSignerMismatch = func(signer, address string) error {
+	return fmt.Errorf("transaction must be signed by %s, wallet address is %s", signer, address)
+}
+
+// ErrMissingSignature happens when a transaction which should have been signed holds
+// no signatures.
+var ErrMissingSignature = fmt.Errorf("transaction has no signatures")
+
+// ErrMultipleSignatures happens when verifying a transaction which holds more than one
+// signature.
+var ErrMultipleSignatures = fmt.Errorf("transaction holds more than one signature")
+
+// ErrMalformedAddress happens when an address isn't a valid bech32 string.
+var ErrMalformedAddress = func(address string) error {
+	return fmt.Errorf("malformed address \"%s\"", address)
+}
+
+// ErrMalformedPubKey happens when a public key isn't a valid secp256k1 public key.
+var ErrMalformedPubKey = fmt.Errorf("malformed public key")
+
+// ErrInvalidSignature happens when a transaction signature doesn't match its sign bytes
+// and public key.
+var ErrInvalidSignature = fmt.Errorf("invalid signature")
+
+// ErrPubKeyMismatch happens when the public key a transaction has been signed with
+// doesn't belong to the expected address.
+var ErrPubKeyMismatch = func(address string) error {
+	return fmt.Errorf("signature public key doesn't belong to %s", address)
+}
+
+// ErrUnsupportedPubKeyType happens when a signature public key has a type other than
+// "tendermint/PubKeySecp256k1".
+var ErrUnsupportedPubKeyType = func(pubKeyType string) error {
+	return fmt.Errorf("unsupported public key type \"%s\"", pubKeyType)
+}
+
+// ErrInvalidMultisigThreshold happens when a multisig threshold isn't between 1 and the
+// number of public keys of the multisig.
+var ErrInvalidMultisigThreshold = func(threshold, keys int) error {
+	return fmt.Errorf("invalid multisig threshold %d for %d public keys", threshold, keys)
+}
+
+// ErrSignerNotInMultisig happens when combining a signature made with a public key
+// which isn't part of the multisig.
+var ErrSignerNotInMultisig = func(pubKey string) error {
+	return fmt.Errorf("public key %s isn't part of the multisig", pubKey)
+}
+
+// ErrInvalidPartialSignature happens when combining a signature which hasn't been made
+// over the transaction sign bytes with the public key it claims.
+var ErrInvalidPartialSignature = func(pubKey string) error {
+	return fmt.Errorf("invalid signature of public key %s", pubKey)
+}
+
+// ErrNotEnoughSignatures happens when combining less signatures than a multisig
+// threshold requires.
+var ErrNotEnoughSignatures = func(have, want int) error {
+	return fmt.Errorf("multisig requires %d signatures, got %d", want, have)
+}
+
+// ErrWrongPassphrase happens when decrypting a private key with the wrong passphrase.
+var ErrWrongPassphrase = fmt.Errorf("wrong passphrase")
+
+// ErrInvalidArmor happens when an ASCII armored private key can't be decoded.
+var ErrInvalidArmor = func(err error) error {
+	return fmt.Errorf("invalid armored private key: %w", err)
+}
+
+// ErrUnsupportedArmor happens when a header or a property of an ASCII armored private
+// key has a value which isn't supported.
+var ErrUnsupportedArmor = func(field, value string) error {
+	return fmt.Errorf("unsupported armor %s \"%s\"", field, value)
+}
+
+// ErrUnsupportedKeyAlgo happens when an armored private key isn't a secp256k1 key.
+var ErrUnsupportedKeyAlgo = func(algo string) error {
+	return fmt.Errorf("unsupported key algorithm \"%s\"", algo)
+}
+
+// ErrMissingHRP happens when the human-readable part of a Wallet is neither provided
+// nor stored along with its key.
+var ErrMissingHRP = fmt.Errorf("missing human-readable part")
+
+// ErrInvalidKeyName happens when a Keystore key name is empty or contains path separators.
+var ErrInvalidKeyName = func(name string) error {
+	return fmt.Errorf("invalid key name \"%s\"", name)
+}
+
+// ErrKeyExists happens when saving a Wallet with the name of an already stored one.
+var ErrKeyExists = func(name string) error {
+	return fmt.Errorf("key \"%s\" already exists", name)
+}
+
+// ErrKeyNotFound happens when a Keystore doesn't hold a key with the requested name.
+var ErrKeyNotFound = func(name string) error {
+	return fmt.Errorf("key \"%s\" not found", name)
+}
+
+// ErrInvalidPrivateKey happens when a private key isn't a valid secp256k1 private key,
+// or a valid BIP-32 extended private key.
+var ErrInvalidPrivateKey = fmt.Errorf("invalid private key")
+
+// ErrImportMismatch happens when a field of an exported Wallet doesn't match the one
+// computed from its private key.
+var ErrImportMismatch = func(field string) error {
+	return fmt.Errorf("exported %s doesn't match the private key", field)
+}
+
+// ErrWatchOnly happens when a watch-only Wallet is asked to perform an operation
+// which requires a private key, like signing.
+var ErrWatchOnly = fmt.Errorf("watch-only wallet has no private key")
+
+// ErrNoChainCode happens when deriving child keys from a Wallet built from a raw
+// public or private key, which carries no BIP-32 chain code.
+var ErrNoChainCode = fmt.Errorf("wallet has no chain code, child keys can't be derived")
+
+// ErrIndexOutOfRange happens when a derivation index doesn't fit in the non-hardened
+// range of BIP-32 indexes.
+var ErrIndexOutOfRange = func(index uint32) error {
+	return fmt.Errorf("derivation index %d out of range", index)
+}
+
+// ErrNotBIP44 happens when a derivation path doesn't conform to BIP-44.
+var ErrNotBIP44 = func(path, reason string) error {
+	return fmt.Errorf("derivation path %s isn't a BIP-44 path: %s", path, reason)
+}
+
+// ErrUnknownTxMode happens when broadcasting a transaction with an unsupported TxMode.
+var ErrUnknownTxMode = func(txMode TxMode) error {
+	return fmt.Errorf("unknown broadcast mode \"%s\"", txMode)
+}
+
+// RPCError is a JSON-RPC error sent by a Tendermint RPC.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+// Error implements the error interface for RPCError.
+func (e *RPCError) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("rpc replied with error %d: %s: %s", e.Code, e.Message, e.Data)
+	}
+
+	return fmt.Sprintf("rpc replied with error %d: %s", e.Code, e.Message)
+}
+
+// ErrUnsupportedProtoMsg happens when encoding to protobuf a message whose type
+// has no protobuf definition known to sacco.
+var ErrUnsupportedProtoMsg = func(aminoType string) error {
+	return fmt.Errorf("message type %s has no registered protobuf encoding", aminoType)
+}
+
+// ErrUnsupportedProposalContent happens when decoding a governance proposal content
+// of an unknown type.
+var ErrUnsupportedProposalContent = func(aminoType string) error {
+	return fmt.Errorf("unsupported proposal content type %s, only %s can be decoded", aminoType, TextProposal{}.AminoType())
+}
+
+// ErrMissingProposalContent happens when encoding a MsgSubmitProposal without Content.
+var ErrMissingProposalContent = fmt.Errorf("missing proposal content")
+
+// ErrInvalidVoteOption happens when encoding a MsgVote with an unknown VoteOption.
+var ErrInvalidVoteOption = func(option VoteOption) error {
+	return fmt.Errorf("invalid vote option \"%s\"", option)
+}
+
+// ErrInvalidGas happens when a transaction Fee gas isn't a valid unsigned integer.
+var ErrInvalidGas = func(gas string) error {
+	return fmt.Errorf("invalid gas \"%s\"", gas)
+}
+
+// ErrNoEndpoints happens when building an EndpointPool without any endpoint.
+var ErrNoEndpoints = fmt.Errorf("no endpoints given")
+
+// ErrMissingNetwork happens when a node replies to /node_info without its network name.
+var ErrMissingNetwork = fmt.Errorf("node info has no network")
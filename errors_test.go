@@ -0,0 +1,133 @@
+package sacco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			"insufficient funds",
+			&TxError{Codespace: "sdk", Code: 5, RawLog: "insufficient account funds"},
+			ErrInsufficientFunds,
+			true,
+		},
+		{
+			"wrapped out of gas",
+			fmt.Errorf("could not broadcast: %w", &TxError{Codespace: "sdk", Code: 11, RawLog: "out of gas"}),
+			ErrOutOfGas,
+			true,
+		},
+		{
+			"invalid sequence",
+			&TxError{Codespace: "sdk", Code: 3, RawLog: "invalid sequence"},
+			ErrInvalidSequence,
+			true,
+		},
+		{
+			"signature verification failed because of a sequence mismatch",
+			&TxError{Codespace: "sdk", Code: 4, RawLog: "signature verification failed; verify correct account sequence and chain-id"},
+			ErrInvalidSequence,
+			true,
+		},
+		{
+			"signature verification failed because of a sequence mismatch is still unauthorized",
+			&TxError{Codespace: "sdk", Code: 4, RawLog: "signature verification failed; verify correct account sequence and chain-id"},
+			ErrUnauthorized,
+			true,
+		},
+		{
+			"account sequence mismatch",
+			&TxError{Codespace: "sdk", Code: 32, RawLog: "account sequence mismatch, expected 6, got 5: incorrect account sequence"},
+			ErrInvalidSequence,
+			true,
+		},
+		{
+			"account sequence mismatch is still wrong sequence",
+			&TxError{Codespace: "sdk", Code: 32, RawLog: "account sequence mismatch, expected 6, got 5: incorrect account sequence"},
+			ErrWrongSequence,
+			true,
+		},
+		{
+			"invalid sequence isn't wrong sequence",
+			&TxError{Codespace: "sdk", Code: 3, RawLog: "invalid sequence"},
+			ErrWrongSequence,
+			false,
+		},
+		{
+			"same code, different codespace",
+			&TxError{Codespace: "docs", Code: 5, RawLog: "document already exists"},
+			ErrInsufficientFunds,
+			false,
+		},
+		{
+			"different code",
+			&TxError{Codespace: "sdk", Code: 5, RawLog: "insufficient account funds"},
+			ErrOutOfGas,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	tests := []struct {
+		name       string
+		jsonResp   string
+		statusResp int
+		want       HTTPError
+	}{
+		{
+			"JSON error",
+			`{"error":"decoding bech32 failed: invalid index of 1"}`,
+			http.StatusInternalServerError,
+			HTTPError{
+				StatusCode: http.StatusInternalServerError,
+				Body:       `{"error":"decoding bech32 failed: invalid index of 1"}`,
+				Message:    "decoding bech32 failed: invalid index of 1",
+			},
+		},
+		{
+			"malformed error",
+			`bad gateway`,
+			http.StatusBadGateway,
+			HTTPError{
+				StatusCode: http.StatusBadGateway,
+				Body:       `bad gateway`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/fakeaccount",
+				httpmock.NewStringResponder(tt.statusResp, tt.jsonResp))
+
+			_, err := NewClient(mockHTTPEndpoint, nil, nil).AccountData(context.Background(), "fakeaccount")
+
+			var httpErr *HTTPError
+			assert.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, tt.want, *httpErr)
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package sacco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+)
+
+// DefaultGasAdjustment is the multiplier applied to gas estimates when
+// GasSettings has no Adjustment.
+const DefaultGasAdjustment = 1.0
+
+// BaseReq is the "base_req" object accepted by the LCD module REST endpoints, like
+// "/bank/accounts/{address}/transfers".
+type BaseReq struct {
+	From          string `json:"from"`
+	Memo          string `json:"memo"`
+	ChainID       string `json:"chain_id"`
+	AccountNumber string `json:"account_number,omitempty"`
+	Sequence      string `json:"sequence,omitempty"`
+	Fees          Coins  `json:"fees,omitempty"`
+	Gas           string `json:"gas,omitempty"`
+	GasAdjustment string `json:"gas_adjustment,omitempty"`
+	Simulate      bool   `json:"simulate"`
+}
+
+// gasEstimateResponse is the LCD response to a simulation request.
+type gasEstimateResponse struct {
+	GasEstimate uint64 `json:"gas_estimate,string"`
+}
+
+// SimulateGas asks the LCD to simulate body on a module REST route, e.g.
+// "/bank/accounts/{address}/transfers", and returns the gas the chain estimates
+// the resulting transaction would consume.
+// body is sent as a JSON object, with its "base_req" field set to baseReq with
+// Simulate set.
+func (c *Client) SimulateGas(ctx context.Context, route string, baseReq BaseReq, body map[string]interface{}) (uint64, error) {
+	baseReq.Simulate = true
+
+	reqBody := map[string]interface{}{}
+	for k, v := range body {
+		reqBody[k] = v
+	}
+	reqBody["base_req"] = baseReq
+
+	requestBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.post(ctx, route, requestBody)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("error while processing simulation request: %w", newHTTPError(resp))
+	}
+
+	var ger gasEstimateResponse
+
+	jdec := json.NewDecoder(resp.Body)
+	err = jdec.Decode(&ger)
+	if err != nil {
+		return 0, fmt.Errorf("could not deserialize gas estimate from lcd: %w", err)
+	}
+
+	return ger.GasEstimate, nil
+}
+
+// GasSimulator is a node able to estimate the gas a transaction would consume.
+// It is implemented by Client and by RPCClient.
+type GasSimulator interface {
+	// SimulateTx simulates tx as if it was signed by signer, and returns the gas the
+	// chain estimates it would consume.
+	SimulateTx(ctx context.Context, tx TransactionPayload, signer *Wallet) (uint64, error)
+}
+
+var (
+	_ GasSimulator = (*Client)(nil)
+	_ GasSimulator = (*RPCClient)(nil)
+)
+
+// SimulateTx simulates tx as if it was signed by signer, and returns the gas the chain
+// estimates it would consume.
+// Legacy amino LCDs can't simulate whole transactions: with SignModeLegacyAmino each
+// message of tx is simulated on its module REST route, and the estimates are summed.
+// The result then overestimates the gas of transactions made of more than one message,
+// since each simulation pays for signature verification on its own.
+// Only messages with a module REST route can be simulated that way, see
+// ErrUnsupportedSimulationMsg; use an RPCClient to simulate any other transaction.
+func (c *Client) SimulateTx(ctx context.Context, tx TransactionPayload, signer *Wallet) (uint64, error) {
+	if c.SignMode != SignModeDirect {
+		return c.simulateLegacy(ctx, tx, signer)
+	}
+
+	accountData, err := c.AccountData(ctx, signer.Address)
+	if err != nil {
+		return 0, fmt.Errorf("could not get Account informations for address %s: %w", signer.Address, err)
+	}
+
+	return c.simulateDirect(ctx, simulationTx(tx), signer, uint64(accountData.Result.Value.Sequence))
+}
+
+// simulateLegacy simulates each message of tx on its module REST route, and returns the
+// sum of the gas estimates.
+func (c *Client) simulateLegacy(ctx context.Context, tx TransactionPayload, signer *Wallet) (uint64, error) {
+	requests := make([]legacySimulationRequest, 0, len(tx.Message))
+	for _, raw := range tx.Message {
+		req, err := newLegacySimulationRequest(raw)
+		if err != nil {
+			return 0, err
+		}
+
+		requests = append(requests, req)
+	}
+
+	nodeInfo, err := c.NodeInfo(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not get LCD node informations: %w", err)
+	}
+
+	baseReq := BaseReq{
+		From:    signer.Address,
+		Memo:    tx.Memo,
+		ChainID: nodeInfo.Info.Network,
+	}
+
+	var total uint64
+	for _, req := range requests {
+		gas, err := c.SimulateGas(ctx, req.route, baseReq, req.body)
+		if err != nil {
+			return 0, err
+		}
+
+		total += gas
+	}
+
+	return total, nil
+}
+
+// legacySimulationRequest is the module REST route and request body equivalent to a
+// transaction message, on legacy amino LCDs.
+type legacySimulationRequest struct {
+	route string
+	body  map[string]interface{}
+}
+
+// newLegacySimulationRequest returns the legacySimulationRequest of the amino JSON
+// encoded message raw.
+func newLegacySimulationRequest(raw json.RawMessage) (legacySimulationRequest, error) {
+	var msgJSON struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	if err := json.Unmarshal(raw, &msgJSON); err != nil {
+		return legacySimulationRequest{}, err
+	}
+
+	switch msgJSON.Type {
+	case MsgSend{}.AminoType():
+		var msg MsgSend
+		if err := json.Unmarshal(msgJSON.Value, &msg); err != nil {
+			return legacySimulationRequest{}, err
+		}
+
+		return legacySimulationRequest{
+			route: fmt.Sprintf("/bank/accounts/%s/transfers", msg.ToAddress),
+			body: map[string]interface{}{
+				"amount": msg.Amount,
+			},
+		}, nil
+	case MsgDelegate{}.AminoType():
+		var msg MsgDelegate
+		if err := json.Unmarshal(msgJSON.Value, &msg); err != nil {
+			return legacySimulationRequest{}, err
+		}
+
+		return legacySimulationRequest{
+			route: fmt.Sprintf("/staking/delegators/%s/delegations", msg.DelegatorAddress),
+			body: map[string]interface{}{
+				"delegator_address": msg.DelegatorAddress,
+				"validator_address": msg.ValidatorAddress,
+				"amount":            msg.Amount,
+			},
+		}, nil
+	case MsgUndelegate{}.AminoType():
+		var msg MsgUndelegate
+		if err := json.Unmarshal(msgJSON.Value, &msg); err != nil {
+			return legacySimulationRequest{}, err
+		}
+
+		return legacySimulationRequest{
+			route: fmt.Sprintf("/staking/delegators/%s/unbonding_delegations", msg.DelegatorAddress),
+			body: map[string]interface{}{
+				"delegator_address": msg.DelegatorAddress,
+				"validator_address": msg.ValidatorAddress,
+				"amount":            msg.Amount,
+			},
+		}, nil
+	case MsgBeginRedelegate{}.AminoType():
+		var msg MsgBeginRedelegate
+		if err := json.Unmarshal(msgJSON.Value, &msg); err != nil {
+			return legacySimulationRequest{}, err
+		}
+
+		return legacySimulationRequest{
+			route: fmt.Sprintf("/staking/delegators/%s/redelegations", msg.DelegatorAddress),
+			body: map[string]interface{}{
+				"delegator_address":     msg.DelegatorAddress,
+				"validator_src_address": msg.ValidatorSrcAddress,
+				"validator_dst_address": msg.ValidatorDstAddress,
+				"amount":                msg.Amount,
+			},
+		}, nil
+	case MsgWithdrawDelegatorReward{}.AminoType():
+		var msg MsgWithdrawDelegatorReward
+		if err := json.Unmarshal(msgJSON.Value, &msg); err != nil {
+			return legacySimulationRequest{}, err
+		}
+
+		return legacySimulationRequest{
+			route: fmt.Sprintf("/distribution/delegators/%s/rewards/%s", msg.DelegatorAddress, msg.ValidatorAddress),
+			body:  map[string]interface{}{},
+		}, nil
+	case MsgSubmitProposal{}.AminoType():
+		var msg MsgSubmitProposal
+		if err := json.Unmarshal(msgJSON.Value, &msg); err != nil {
+			return legacySimulationRequest{}, err
+		}
+
+		// UnmarshalJSON only decodes TextProposal contents
+		content := msg.Content.(TextProposal)
+
+		return legacySimulationRequest{
+			route: "/gov/proposals",
+			body: map[string]interface{}{
+				"title":           content.Title,
+				"description":     content.Description,
+				"proposal_type":   "Text",
+				"proposer":        msg.Proposer,
+				"initial_deposit": msg.InitialDeposit,
+			},
+		}, nil
+	case MsgVote{}.AminoType():
+		var msg MsgVote
+		if err := json.Unmarshal(msgJSON.Value, &msg); err != nil {
+			return legacySimulationRequest{}, err
+		}
+
+		return legacySimulationRequest{
+			route: fmt.Sprintf("/gov/proposals/%d/votes", msg.ProposalID),
+			body: map[string]interface{}{
+				"voter":  msg.Voter,
+				"option": msg.Option,
+			},
+		}, nil
+	}
+
+	return legacySimulationRequest{}, ErrUnsupportedSimulationMsg(msgJSON.Type)
+}
+
+// simulationTx returns a copy of tx suitable for simulation, whose Fee gas is
+// zero if it wasn't set yet.
+func simulationTx(tx TransactionPayload) TransactionPayload {
+	if tx.Fee.Gas == "" {
+		tx.Fee.Gas = "0"
+	}
+
+	tx.Signatures = nil
+
+	return tx
+}
+
+// GasSettings describes how to turn a gas estimate into the Fee of a transaction.
+type GasSettings struct {
+	// Adjustment is the multiplier applied to gas estimates.
+	// If zero, DefaultGasAdjustment is used.
+	Adjustment float64
+
+	// Price is the price of a single gas unit, e.g. "0.025ucommercio".
+	Price string
+}
+
+// Fee returns the Fee of a transaction estimated to consume gasEstimate gas.
+// The fee amount is the adjusted gas times the gas price, rounded up.
+func (gs GasSettings) Fee(gasEstimate uint64) (Fee, error) {
+	price, err := ParseDecCoin(gs.Price)
+	if err != nil {
+		return Fee{}, ErrInvalidGasPrice(gs.Price)
+	}
+
+	adjustment := gs.Adjustment
+	if adjustment == 0 {
+		adjustment = DefaultGasAdjustment
+	}
+
+	if adjustment < 0 {
+		return Fee{}, ErrInvalidGasAdjustment(adjustment)
+	}
+
+	gas, err := adjustGas(gasEstimate, adjustment)
+	if err != nil {
+		return Fee{}, err
+	}
+
+	fee, err := price.MulGas(gas)
+	if err != nil {
+		return Fee{}, ErrInvalidGasPrice(gs.Price)
+	}
+
+	return Fee{
+		Amount: []Coin{fee},
+		Gas:    strconv.FormatUint(gas, 10),
+	}, nil
+}
+
+// FillFee simulates tx against sim as if it was signed by signer, and sets tx Fee
+// to the one computed by gs from the gas estimate.
+func (gs GasSettings) FillFee(ctx context.Context, sim GasSimulator, tx *TransactionPayload, signer *Wallet) error {
+	gasEstimate, err := sim.SimulateTx(ctx, *tx, signer)
+	if err != nil {
+		return err
+	}
+
+	fee, err := gs.Fee(gasEstimate)
+	if err != nil {
+		return err
+	}
+
+	tx.Fee = fee
+
+	return nil
+}
+
+// adjustGas returns gasEstimate times adjustment, rounded up.
+// adjustment is read as the shortest decimal representing it, so that exact
+// products like 1.1 * 100000 aren't inflated by floating point errors.
+func adjustGas(gasEstimate uint64, adjustment float64) (uint64, error) {
+	adj, ok := new(big.Rat).SetString(strconv.FormatFloat(adjustment, 'f', -1, 64))
+	if !ok {
+		return 0, ErrInvalidGasAdjustment(adjustment)
+	}
+
+	gas := adj.Mul(adj, new(big.Rat).SetInt(new(big.Int).SetUint64(gasEstimate)))
+
+	quo, rem := new(big.Int).QuoRem(gas.Num(), gas.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+
+	if !quo.IsUint64() {
+		return 0, ErrInvalidGasAdjustment(adjustment)
+	}
+
+	return quo.Uint64(), nil
+}
@@ -0,0 +1,394 @@
+package sacco
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	sdkTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SimulateGas(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+	route := "/bank/accounts/" + testAccAddress + "/transfers"
+
+	tests := []struct {
+		name       string
+		jsonResp   string
+		statusResp int
+		want       uint64
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"successful simulation",
+			`{"gas_estimate":"52541"}`,
+			http.StatusOK,
+			52541,
+			assert.NoError,
+		},
+		{
+			"failed simulation",
+			`{"error":"insufficient account funds"}`,
+			http.StatusBadRequest,
+			0,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint+route,
+				func(req *http.Request) (*http.Response, error) {
+					body, err := ioutil.ReadAll(req.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"amount":[{"denom":"uatom","amount":"10"}],"base_req":{"from":"`+testAccAddress+`","memo":"","chain_id":"cosmoshub-3","simulate":true}}`, string(body))
+
+					return httpmock.NewStringResponse(tt.statusResp, tt.jsonResp), nil
+				})
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			got, err := c.SimulateGas(
+				context.Background(),
+				route,
+				BaseReq{From: testAccAddress, ChainID: "cosmoshub-3"},
+				map[string]interface{}{"amount": json.RawMessage(`[{"denom":"uatom","amount":"10"}]`)},
+			)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGasSettings_Fee(t *testing.T) {
+	tests := []struct {
+		name        string
+		gs          GasSettings
+		gasEstimate uint64
+		want        Fee
+		assertion   assert.ErrorAssertionFunc
+	}{
+		{
+			"default adjustment",
+			GasSettings{Price: "0.025ucommercio"},
+			200000,
+			Fee{Amount: []Coin{{Denom: "ucommercio", Amount: "5000"}}, Gas: "200000"},
+			assert.NoError,
+		},
+		{
+			"adjusted gas, fee rounded up",
+			GasSettings{Adjustment: 1.5, Price: "0.025ucommercio"},
+			52541,
+			Fee{Amount: []Coin{{Denom: "ucommercio", Amount: "1971"}}, Gas: "78812"},
+			assert.NoError,
+		},
+		{
+			"integer gas price",
+			GasSettings{Adjustment: 1.2, Price: "1uatom"},
+			100000,
+			Fee{Amount: []Coin{{Denom: "uatom", Amount: "120000"}}, Gas: "120000"},
+			assert.NoError,
+		},
+		{
+			"exact adjusted gas isn't rounded up",
+			GasSettings{Adjustment: 1.1, Price: "1uatom"},
+			100000,
+			Fee{Amount: []Coin{{Denom: "uatom", Amount: "110000"}}, Gas: "110000"},
+			assert.NoError,
+		},
+		{
+			"gas price without denom",
+			GasSettings{Price: "0.025"},
+			100000,
+			Fee{},
+			assert.Error,
+		},
+		{
+			"negative adjustment",
+			GasSettings{Adjustment: -1, Price: "0.025ucommercio"},
+			100000,
+			Fee{},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.gs.Fee(tt.gasEstimate)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_SimulateTx(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:1317"
+
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := testDirectTx(t)
+	tx.Fee = Fee{}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/auth/v1beta1/accounts/"+w.Address,
+		httpmock.NewStringResponder(http.StatusOK, `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"`+w.Address+`","pub_key":null,"account_number":"11","sequence":"3"}}`))
+
+	pubKey, err := w.publicKey.ECPubKey()
+	require.NoError(t, err)
+
+	bodyBytes, err := protoTxBody(tx)
+	require.NoError(t, err)
+
+	authInfoBytes, err := protoAuthInfo(pubKey.SerializeCompressed(), 3, Fee{Gas: "0"})
+	require.NoError(t, err)
+
+	want := TxRaw{BodyBytes: bodyBytes, AuthInfoBytes: authInfoBytes, Signatures: [][]byte{{}}}
+
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/cosmos/tx/v1beta1/simulate",
+		func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				TxBytes string `json:"tx_bytes"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, base64.StdEncoding.EncodeToString(want.Marshal()), body.TxBytes)
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"gas_info":{"gas_wanted":"0","gas_used":"52541"},"result":{"data":"","log":"","events":[]}}`), nil
+		})
+
+	c := NewClient(mockHTTPEndpoint, nil, nil)
+	c.SignMode = SignModeDirect
+
+	got, err := c.SimulateTx(context.Background(), tx, w)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(52541), got)
+}
+
+func TestClient_SimulateTx_legacy(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:1317"
+	valAddress := "cosmosvaloper1tflk30mq5vgqjdly92kkhhq3raev2hnz6eete3"
+
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := TransactionPayload{Memo: "sacco"}
+	require.NoError(t, tx.AddMsgs(
+		MsgSend{
+			FromAddress: w.Address,
+			ToAddress:   testAccAddress,
+			Amount:      Coins{{Denom: "uatom", Amount: "10"}},
+		},
+		MsgDelegate{
+			DelegatorAddress: w.Address,
+			ValidatorAddress: valAddress,
+			Amount:           Coin{Denom: "uatom", Amount: "10"},
+		},
+	))
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/node_info",
+		httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"test-chain-jVvnJ6"}}`))
+
+	wantBaseReq := `{"from":"` + w.Address + `","memo":"sacco","chain_id":"test-chain-jVvnJ6","simulate":true}`
+
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/bank/accounts/"+testAccAddress+"/transfers",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"base_req":`+wantBaseReq+`,"amount":[{"denom":"uatom","amount":"10"}]}`, string(body))
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"gas_estimate":"52541"}`), nil
+		})
+
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/staking/delegators/"+w.Address+"/delegations",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"base_req":`+wantBaseReq+`,"delegator_address":"`+w.Address+`","validator_address":"`+valAddress+`","amount":{"denom":"uatom","amount":"10"}}`, string(body))
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"gas_estimate":"101234"}`), nil
+		})
+
+	c := NewClient(mockHTTPEndpoint, nil, nil)
+
+	got, err := c.SimulateTx(context.Background(), tx, w)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(52541+101234), got)
+
+	// messages without a module REST route can't be simulated
+	require.NoError(t, tx.AddMsgs(MsgMultiSend{}))
+
+	_, err = c.SimulateTx(context.Background(), tx, w)
+	assert.Equal(t, ErrUnsupportedSimulationMsg(MsgMultiSend{}.AminoType()), err)
+}
+
+func Test_newLegacySimulationRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		msg       Msg
+		want      legacySimulationRequest
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"undelegate",
+			MsgUndelegate{DelegatorAddress: "del", ValidatorAddress: "val", Amount: Coin{Denom: "uatom", Amount: "10"}},
+			legacySimulationRequest{
+				route: "/staking/delegators/del/unbonding_delegations",
+				body: map[string]interface{}{
+					"delegator_address": "del",
+					"validator_address": "val",
+					"amount":            Coin{Denom: "uatom", Amount: "10"},
+				},
+			},
+			assert.NoError,
+		},
+		{
+			"redelegate",
+			MsgBeginRedelegate{DelegatorAddress: "del", ValidatorSrcAddress: "src", ValidatorDstAddress: "dst", Amount: Coin{Denom: "uatom", Amount: "10"}},
+			legacySimulationRequest{
+				route: "/staking/delegators/del/redelegations",
+				body: map[string]interface{}{
+					"delegator_address":     "del",
+					"validator_src_address": "src",
+					"validator_dst_address": "dst",
+					"amount":                Coin{Denom: "uatom", Amount: "10"},
+				},
+			},
+			assert.NoError,
+		},
+		{
+			"withdraw delegator reward",
+			MsgWithdrawDelegatorReward{DelegatorAddress: "del", ValidatorAddress: "val"},
+			legacySimulationRequest{
+				route: "/distribution/delegators/del/rewards/val",
+				body:  map[string]interface{}{},
+			},
+			assert.NoError,
+		},
+		{
+			"submit text proposal",
+			MsgSubmitProposal{Content: TextProposal{Title: "title", Description: "description"}, InitialDeposit: Coins{{Denom: "uatom", Amount: "10"}}, Proposer: "proposer"},
+			legacySimulationRequest{
+				route: "/gov/proposals",
+				body: map[string]interface{}{
+					"title":           "title",
+					"description":     "description",
+					"proposal_type":   "Text",
+					"proposer":        "proposer",
+					"initial_deposit": Coins{{Denom: "uatom", Amount: "10"}},
+				},
+			},
+			assert.NoError,
+		},
+		{
+			"vote",
+			MsgVote{ProposalID: 3, Voter: "voter", Option: OptionYes},
+			legacySimulationRequest{
+				route: "/gov/proposals/3/votes",
+				body: map[string]interface{}{
+					"voter":  "voter",
+					"option": OptionYes,
+				},
+			},
+			assert.NoError,
+		},
+		{
+			"multisend has no module REST route",
+			MsgMultiSend{},
+			legacySimulationRequest{},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tx TransactionPayload
+			require.NoError(t, tx.AddMsgs(tt.msg))
+
+			got, err := newLegacySimulationRequest(tx.Message[0])
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRPCClient_SimulateTx(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:26657"
+
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := testDirectTx(t)
+	tx.Fee = Fee{}
+
+	c := NewRPCClient(mockHTTPEndpoint, nil, nil)
+	gasUsed := base64.StdEncoding.EncodeToString(c.Codec.MustMarshalBinaryLengthPrefixed(uint64(52541)))
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint, func(req *http.Request) (*http.Response, error) {
+		var rpcReq struct {
+			Method string `json:"method"`
+			Params struct {
+				Path string `json:"path"`
+				Data string `json:"data"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&rpcReq))
+		assert.Equal(t, "abci_query", rpcReq.Method)
+		assert.Equal(t, simulateQueryPath, rpcReq.Params.Path)
+
+		txBytes, err := hex.DecodeString(rpcReq.Params.Data)
+		require.NoError(t, err)
+
+		decoded, err := auth.DefaultTxDecoder(c.Codec)(txBytes)
+		require.NoError(t, err)
+
+		stdTx := decoded.(auth.StdTx)
+		assert.Len(t, stdTx.Msgs, 1)
+		assert.Equal(t, uint64(0), stdTx.Fee.Gas)
+		require.Len(t, stdTx.Signatures, 1)
+		assert.Empty(t, stdTx.Signatures[0].Signature)
+		assert.Equal(t, w.Address, sdkTypes.AccAddress(stdTx.Signatures[0].PubKey.Address()).String())
+
+		return httpmock.NewStringResponse(http.StatusOK, `{"jsonrpc":"2.0","id":"sacco","result":{"response":{"code":0,"log":"","value":"`+gasUsed+`","height":"1590"}}}`), nil
+	})
+
+	got, err := c.SimulateTx(context.Background(), tx, w)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(52541), got)
+}
+
+// staticSimulator is a GasSimulator which always estimates the same gas.
+type staticSimulator uint64
+
+func (s staticSimulator) SimulateTx(context.Context, TransactionPayload, *Wallet) (uint64, error) {
+	return uint64(s), nil
+}
+
+func TestGasSettings_FillFee(t *testing.T) {
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := testDirectTx(t)
+
+	gs := GasSettings{Adjustment: 1.5, Price: "0.025ucommercio"}
+	assert.NoError(t, gs.FillFee(context.Background(), staticSimulator(52541), &tx, w))
+	assert.Equal(t, Fee{Amount: []Coin{{Denom: "ucommercio", Amount: "1971"}}, Gas: "78812"}, tx.Fee)
+
+	gs.Price = "0.025"
+	assert.Error(t, gs.FillFee(context.Background(), staticSimulator(52541), &tx, w))
+}
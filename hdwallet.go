@@ -0,0 +1,163 @@
+package sacco
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// cosmosCoinType is the BIP-44 coin type of Cosmos accounts.
+const cosmosCoinType = 118
+
+// HDWallet derives the Wallets of many accounts and address indexes from a single
+// mnemonic, along CosmosDerivationPath-style paths: m/44'/118'/account'/0/index.
+// The seed and the intermediate keys are computed once, which makes deriving many
+// Wallets from an HDWallet much cheaper than calling FromMnemonic for each of them.
+// HDWallet is safe for concurrent use.
+type HDWallet struct {
+	hrp    string
+	master *hdkeychain.ExtendedKey
+
+	mu     sync.Mutex
+	chains map[uint32]*hdkeychain.ExtendedKey
+}
+
+// NewHDWallet returns a new HDWallet instance given a human-readable part, a mnemonic
+// and a BIP-39 passphrase, which can be empty.
+func NewHDWallet(hrp, mnemonic, passphrase string) (*HDWallet, error) {
+	if !IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	params := chaincfg.MainNetParams
+	master, err := hdkeychain.NewMaster(newSeed(mnemonic, passphrase), &params)
+	if err != nil {
+		return nil, ErrKeyGeneration(err)
+	}
+
+	return &HDWallet{
+		hrp:    hrp,
+		master: master,
+		chains: map[uint32]*hdkeychain.ExtendedKey{},
+	}, nil
+}
+
+// chain returns the m/44'/118'/account'/0 extended key.
+func (hd *HDWallet) chain(account uint32) (*hdkeychain.ExtendedKey, error) {
+	if account >= hdkeychain.HardenedKeyStart {
+		return nil, ErrIndexOutOfRange(account)
+	}
+
+	hd.mu.Lock()
+	defer hd.mu.Unlock()
+
+	if chain, ok := hd.chains[account]; ok {
+		return chain, nil
+	}
+
+	chain := hd.master
+	for _, index := range []uint32{
+		44 + hdkeychain.HardenedKeyStart,
+		cosmosCoinType + hdkeychain.HardenedKeyStart,
+		account + hdkeychain.HardenedKeyStart,
+		0,
+	} {
+		var err error
+		chain, err = chain.Child(index)
+		if err != nil {
+			return nil, ErrKeyGeneration(err)
+		}
+	}
+
+	// ExtendedKey lazily caches its public key, compute it now so that
+	// concurrent Child calls don't race on it
+	if _, err := chain.ECPubKey(); err != nil {
+		return nil, ErrKeyGeneration(err)
+	}
+
+	hd.chains[account] = chain
+
+	return chain, nil
+}
+
+// Wallet returns the Wallet at path m/44'/118'/account'/0/index.
+func (hd *HDWallet) Wallet(account, index uint32) (*Wallet, error) {
+	if index >= hdkeychain.HardenedKeyStart {
+		return nil, ErrIndexOutOfRange(index)
+	}
+
+	chain, err := hd.chain(account)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := chain.Child(index)
+	if err != nil {
+		return nil, ErrKeyGeneration(err)
+	}
+
+	return walletFromExtendedKey(hd.hrp, key, NewBIP44DerivationPath(cosmosCoinType, account, 0, index).String())
+}
+
+// Wallets returns the count Wallets of account starting from index from, deriving
+// them concurrently.
+// The i-th returned Wallet is the one at path m/44'/118'/account'/0/(from+i).
+func (hd *HDWallet) Wallets(account, from, count uint32) ([]*Wallet, error) {
+	if uint64(from)+uint64(count) > hdkeychain.HardenedKeyStart {
+		return nil, ErrIndexOutOfRange(from + count - 1)
+	}
+
+	// derive the chain key once, before spawning workers
+	if _, err := hd.chain(account); err != nil {
+		return nil, err
+	}
+
+	wallets := make([]*Wallet, count)
+	errs := make([]error, count)
+	indexes := make(chan uint32)
+
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				wallets[i], errs[i] = hd.Wallet(account, from+i)
+			}
+		}()
+	}
+
+	for i := uint32(0); i < count; i++ {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return wallets, nil
+}
+
+// Addresses returns the addresses of the count Wallets of account starting from
+// index from, as Wallets does.
+func (hd *HDWallet) Addresses(account, from, count uint32) ([]string, error) {
+	wallets, err := hd.Wallets(account, from, count)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, len(wallets))
+	for i, w := range wallets {
+		addresses[i] = w.Address
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,70 @@
+package sacco
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHDWallet_Wallet(t *testing.T) {
+	hd, err := NewHDWallet("did:com:", testMnemonic, "")
+	assert.NoError(t, err)
+
+	for _, ai := range [][2]uint32{{0, 0}, {0, 7}, {3, 1}} {
+		want, err := FromMnemonic("did:com:", testMnemonic, fmt.Sprintf("m/44'/118'/%d'/0/%d", ai[0], ai[1]))
+		assert.NoError(t, err)
+
+		got, err := hd.Wallet(ai[0], ai[1])
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err = hd.Wallet(0, 0x80000000)
+	assert.Error(t, err)
+
+	_, err = hd.Wallet(0x80000000, 0)
+	assert.Error(t, err)
+
+	_, err = NewHDWallet("did:com:", "invalid mnemonic", "")
+	assert.Equal(t, ErrInvalidMnemonic, err)
+}
+
+func TestHDWallet_passphrase(t *testing.T) {
+	hd, err := NewHDWallet("did:com:", testMnemonic, "commercio")
+	assert.NoError(t, err)
+
+	want, err := FromMnemonicWithPassphrase("did:com:", testMnemonic, "commercio", CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	got, err := hd.Wallet(0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestHDWallet_Wallets(t *testing.T) {
+	hd, err := NewHDWallet("did:com:", testMnemonic, "")
+	assert.NoError(t, err)
+
+	wallets, err := hd.Wallets(1, 10, 50)
+	assert.NoError(t, err)
+	assert.Len(t, wallets, 50)
+
+	addresses, err := hd.Addresses(1, 10, 50)
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 50)
+
+	for i, w := range wallets {
+		want, err := hd.Wallet(1, 10+uint32(i))
+		assert.NoError(t, err)
+		assert.Equal(t, want, w)
+		assert.Equal(t, want.Address, addresses[i])
+	}
+
+	wallets, err = hd.Wallets(0, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, wallets)
+
+	_, err = hd.Wallets(0, 0x7fffffff, 2)
+	assert.Error(t, err)
+}
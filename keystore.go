@@ -0,0 +1,370 @@
+package sacco
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/tendermint/crypto/bcrypt"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/armor"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	// armorBlockType is the armor block type of encrypted private keys.
+	armorBlockType = "TENDERMINT PRIVATE KEY"
+
+	// armorAlgo is the key algorithm stored in the armor "type" header.
+	armorAlgo = "secp256k1"
+
+	// armorKDF is the key derivation function stored in the armor "kdf" header, the
+	// only one the cosmos-sdk "keys import" command supports.
+	armorKDF = "bcrypt"
+
+	// armorBcryptCost is the bcrypt cost used by the cosmos-sdk "keys export" command.
+	armorBcryptCost = 12
+
+	// armor headers describing how the private key has been encrypted.
+	armorHeaderType = "type"
+	armorHeaderKDF  = "kdf"
+	armorHeaderSalt = "salt"
+
+	// armor headers holding the Wallet informations that can't be recovered from
+	// the private key alone.
+	armorHeaderHRP  = "hrp"
+	armorHeaderPath = "path"
+	armorHeaderXPub = "xpub"
+
+	// keystoreExt is the extension of keystore files.
+	keystoreExt = ".armor"
+)
+
+// EncryptArmor encrypts w's private key with passphrase, and returns it in the ASCII
+// armored format used by the cosmos-sdk "keys export" command, so that it can be
+// imported with "keys import".
+// The key is encrypted with xsalsa20-poly1305, with a key derived from passphrase
+// with bcrypt (cost 12) and a random salt. bcrypt is used rather than scrypt or
+// argon2 because it's the only key derivation function the cosmos-sdk armor format
+// supports: any other one would make the exported keys unusable with "keys import".
+// w's human-readable part, derivation path and extended public key are stored
+// in clear in the armor headers.
+func (w Wallet) EncryptArmor(passphrase string) (string, error) {
+	if w.IsWatchOnly() {
+		return "", ErrWatchOnly
+	}
+
+	pk, err := w.keyPair.ECPrivKey()
+	if err != nil {
+		return "", err
+	}
+
+	var privKey secp256k1.PrivKeySecp256k1
+	copy(privKey[:], pk.Serialize())
+
+	salt := crypto.CRandBytes(16)
+
+	key, err := armorKey(salt, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	headers := map[string]string{
+		armorHeaderKDF:  armorKDF,
+		armorHeaderSalt: fmt.Sprintf("%X", salt),
+		armorHeaderType: armorAlgo,
+	}
+
+	data := xsalsa20symmetric.EncryptSymmetric(privKey.Bytes(), key)
+
+	headers[armorHeaderHRP] = w.HRP
+	headers[armorHeaderXPub] = w.PublicKey
+	if w.Path != "" {
+		headers[armorHeaderPath] = w.Path
+	}
+
+	return armor.EncodeArmor(armorBlockType, headers, data), nil
+}
+
+// armorKey returns the 32 bytes symmetric key derived from passphrase and salt, the
+// same way the cosmos-sdk does.
+func armorKey(salt []byte, passphrase string) ([]byte, error) {
+	key, err := bcrypt.GenerateFromPassword(salt, []byte(passphrase), armorBcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Sha256(key), nil
+}
+
+// decryptArmor decrypts the ASCII armored private key armored with passphrase, and
+// returns it along with the armor headers.
+func decryptArmor(armored, passphrase string) (crypto.PrivKey, map[string]string, error) {
+	blockType, headers, data, err := armor.DecodeArmor(armored)
+	if err != nil {
+		return nil, nil, ErrInvalidArmor(err)
+	}
+
+	if blockType != armorBlockType {
+		return nil, nil, ErrInvalidArmor(ErrUnsupportedArmor("block type", blockType))
+	}
+
+	if headers[armorHeaderKDF] != armorKDF {
+		return nil, nil, ErrInvalidArmor(ErrUnsupportedArmor(armorHeaderKDF, headers[armorHeaderKDF]))
+	}
+
+	salt, err := hex.DecodeString(headers[armorHeaderSalt])
+	if err != nil || len(salt) == 0 {
+		return nil, nil, ErrInvalidArmor(ErrUnsupportedArmor(armorHeaderSalt, headers[armorHeaderSalt]))
+	}
+
+	key, err := armorKey(salt, passphrase)
+	if err != nil {
+		return nil, nil, ErrInvalidArmor(err)
+	}
+
+	// nonce (24) || xsalsa20-poly1305 ciphertext
+	if len(data) <= 24+secretbox.Overhead {
+		return nil, nil, ErrInvalidArmor(ErrUnsupportedArmor("ciphertext length", fmt.Sprint(len(data))))
+	}
+
+	var (
+		nonce     [24]byte
+		secretKey [32]byte
+	)
+	copy(nonce[:], data[:24])
+	copy(secretKey[:], key)
+
+	plaintext, ok := secretbox.Open(nil, data[24:], &nonce, &secretKey)
+	if !ok {
+		return nil, nil, ErrWrongPassphrase
+	}
+
+	privKey, err := cryptoAmino.PrivKeyFromBytes(plaintext)
+	if err != nil {
+		return nil, nil, ErrInvalidArmor(err)
+	}
+
+	return privKey, headers, nil
+}
+
+// FromArmor returns a new Wallet instance given an ASCII armored private key produced
+// by EncryptArmor or by the cosmos-sdk "keys export" command, and the passphrase
+// it has been encrypted with.
+// If hrp is empty, the human-readable part stored by EncryptArmor is used.
+func FromArmor(hrp, armored, passphrase string) (*Wallet, error) {
+	privKey, headers, err := decryptArmor(armored, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	algo := headers[armorHeaderType]
+	if algo == "" {
+		algo = armorAlgo
+	}
+
+	secpPrivKey, ok := privKey.(secp256k1.PrivKeySecp256k1)
+	if !ok || algo != armorAlgo {
+		return nil, ErrUnsupportedKeyAlgo(algo)
+	}
+
+	if hrp == "" {
+		hrp = headers[armorHeaderHRP]
+	}
+
+	if hrp == "" {
+		return nil, ErrMissingHRP
+	}
+
+	var key *hdkeychain.ExtendedKey
+	if xpub := headers[armorHeaderXPub]; xpub != "" {
+		key, err = extendedPrivKey(xpub, secpPrivKey[:])
+	} else {
+		// keys exported by the cosmos-sdk carry no chain code
+		key, err = extendedKeyFromPrivateKey(secpPrivKey[:])
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return walletFromExtendedKey(hrp, key, headers[armorHeaderPath])
+}
+
+// extendedPrivKey returns the extended private key made of privKey and the chain code,
+// depth, parent fingerprint and child number of the serialized extended public key xpub.
+func extendedPrivKey(xpub string, privKey []byte) (*hdkeychain.ExtendedKey, error) {
+	pubKey, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil || pubKey.IsPrivate() {
+		return nil, ErrInvalidArmor(ErrMalformedPubKey)
+	}
+
+	// version (4) || depth (1) || parent fingerprint (4) || child number (4) ||
+	// chain code (32) || public key (33) || checksum (4)
+	payload := base58.Decode(xpub)
+
+	key := hdkeychain.NewExtendedKey(
+		chaincfg.MainNetParams.HDPrivateKeyID[:],
+		privKey,
+		payload[13:45],
+		payload[5:9],
+		payload[4],
+		binary.BigEndian.Uint32(payload[9:13]),
+		true,
+	)
+
+	neutered, err := key.Neuter()
+	if err != nil {
+		return nil, ErrCouldNotNeuter(err)
+	}
+
+	// the private key must match the public one
+	neuteredPayload := base58.Decode(neutered.String())
+	if !bytes.Equal(neuteredPayload[45:78], payload[45:78]) {
+		return nil, ErrInvalidArmor(ErrPubKeyMismatch(xpub))
+	}
+
+	return key, nil
+}
+
+// Keystore persists Wallets in a directory, each one encrypted with its own passphrase
+// in the format produced by Wallet.EncryptArmor.
+type Keystore struct {
+	Dir string
+}
+
+// NewKeystore returns a new Keystore instance storing Wallets in dir, which is created
+// if it doesn't exist.
+func NewKeystore(dir string) (*Keystore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &Keystore{Dir: dir}, nil
+}
+
+// path returns the path of the keystore file holding the Wallet called name.
+func (ks *Keystore) path(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", ErrInvalidKeyName(name)
+	}
+
+	return filepath.Join(ks.Dir, name+keystoreExt), nil
+}
+
+// Save encrypts w with passphrase, and stores it as name.
+// Save doesn't overwrite existing Wallets, and either stores the whole Wallet or
+// nothing at all.
+func (ks *Keystore) Save(name string, w *Wallet, passphrase string) error {
+	path, err := ks.path(name)
+	if err != nil {
+		return err
+	}
+
+	armored, err := w.EncryptArmor(passphrase)
+	if err != nil {
+		return err
+	}
+
+	// the Wallet is written to a temporary file first, so that a crash never leaves
+	// a truncated keystore file behind
+	f, err := ioutil.TempFile(ks.Dir, "."+name+keystoreExt+".tmp")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := f.WriteString(armored); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// unlike a rename, a hard link never replaces an existing Wallet
+	if err := os.Link(tmpPath, path); err != nil {
+		if os.IsExist(err) {
+			return ErrKeyExists(name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Load decrypts and returns the Wallet stored as name.
+func (ks *Keystore) Load(name, passphrase string) (*Wallet, error) {
+	path, err := ks.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	armored, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrKeyNotFound(name)
+		}
+
+		return nil, err
+	}
+
+	return FromArmor("", string(armored), passphrase)
+}
+
+// List returns the sorted names of the Wallets stored in ks.
+func (ks *Keystore) List() ([]string, error) {
+	files, err := ioutil.ReadDir(ks.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), keystoreExt) {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(f.Name(), keystoreExt))
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Delete removes the Wallet stored as name.
+func (ks *Keystore) Delete(name string) error {
+	path, err := ks.path(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return ErrKeyNotFound(name)
+	}
+
+	return err
+}
@@ -0,0 +1,118 @@
+package sacco
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestWallet_EncryptArmor(t *testing.T) {
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	armored, err := w.EncryptArmor("passphrase")
+	assert.NoError(t, err)
+
+	// the armored key can be imported by the cosmos-sdk
+	privKey, algo, err := mintkey.UnarmorDecryptPrivKey(armored, "passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, "secp256k1", algo)
+
+	pk, err := w.keyPair.ECPrivKey()
+	assert.NoError(t, err)
+
+	var want secp256k1.PrivKeySecp256k1
+	copy(want[:], pk.Serialize())
+	assert.Equal(t, want, privKey)
+
+	// and back into a Wallet
+	got, err := FromArmor("", armored, "passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, w, got)
+
+	_, err = FromArmor("", armored, "wrong passphrase")
+	assert.Equal(t, ErrWrongPassphrase, err)
+
+	_, err = FromArmor("", "not an armored key", "passphrase")
+	assert.Error(t, err)
+}
+
+func TestFromArmor_cosmosSDK(t *testing.T) {
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	pk, err := w.keyPair.ECPrivKey()
+	assert.NoError(t, err)
+
+	var privKey secp256k1.PrivKeySecp256k1
+	copy(privKey[:], pk.Serialize())
+
+	// an armored key exported by the cosmos-sdk "keys export" command
+	armored := mintkey.EncryptArmorPrivKey(privKey, "passphrase", "secp256k1")
+
+	_, err = FromArmor("", armored, "passphrase")
+	assert.Equal(t, ErrMissingHRP, err)
+
+	got, err := FromArmor("did:com:", armored, "passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, w.Address, got.Address)
+	assert.Equal(t, w.PublicKeyBech32, got.PublicKeyBech32)
+
+	signed, err := got.Sign(TransactionPayload{Fee: Fee{Amount: []Coin{}, Gas: "200000"}}, "test-chain-jVvnJ6", "11", "0")
+	assert.NoError(t, err)
+	assert.NoError(t, Verify(signed, "test-chain-jVvnJ6", "11", "0", w.Address))
+}
+
+func TestKeystore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sacco-keystore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ks, err := NewKeystore(dir)
+	assert.NoError(t, err)
+
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	names, err := ks.List()
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	assert.NoError(t, ks.Save("treasury", w, "passphrase"))
+	assert.Equal(t, ErrKeyExists("treasury"), ks.Save("treasury", w, "passphrase"))
+	assert.Equal(t, ErrInvalidKeyName("../treasury"), ks.Save("../treasury", w, "passphrase"))
+
+	info, err := os.Stat(dir + "/treasury.armor")
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	names, err = ks.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"treasury"}, names)
+
+	// no temporary file is left behind
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	got, err := ks.Load("treasury", "passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, w, got)
+
+	_, err = ks.Load("treasury", "wrong passphrase")
+	assert.Equal(t, ErrWrongPassphrase, err)
+
+	_, err = ks.Load("unknown", "passphrase")
+	assert.Equal(t, ErrKeyNotFound("unknown"), err)
+
+	assert.NoError(t, ks.Delete("treasury"))
+	assert.Equal(t, ErrKeyNotFound("treasury"), ks.Delete("treasury"))
+
+	names, err = ks.List()
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+}
@@ -1,63 +1,135 @@
 package sacco
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
-// Retrieve the account data related to the given wallet address, like
+// AccountData retrieves the account data related to the given wallet address, like
 // account number and sequence number.
-func getAccountData(lcdEndpoint, address string) (AccountData, error) {
-	endpoint := fmt.Sprintf("%s/auth/accounts/%s", lcdEndpoint, address)
-
-	resp, err := http.Get(endpoint)
+// If the account has never been used on chain, an error wrapping ErrAccountNotOnline
+// is returned.
+func (c *Client) AccountData(ctx context.Context, address string) (AccountData, error) {
+	account, err := c.account(ctx, address)
 	if err != nil {
 		return AccountData{}, err
 	}
 
+	return newAccountData(account), nil
+}
+
+// newAccountData returns the AccountData holding the address, account number and
+// sequence of account.
+func newAccountData(account Account) AccountData {
+	return AccountData{
+		Result: AccountDataResult{
+			Value: AccountDataValue{
+				Address:       account.Address,
+				AccountNumber: account.AccountNumber,
+				Sequence:      account.Sequence,
+			},
+		},
+	}
+}
+
+// NodeInfo returns useful information of the full node, like the Network
+// (chain) name.
+func (c *Client) NodeInfo(ctx context.Context) (NodeInfo, error) {
+	if c.SignMode == SignModeDirect {
+		return c.directNodeInfo(ctx)
+	}
+
+	resp, err := c.get(ctx, "/node_info")
+	if err != nil {
+		return NodeInfo{}, err
+	}
+
+	defer resp.Body.Close()
+
+	var nodeInfo NodeInfo
 	jdec := json.NewDecoder(resp.Body)
+	err = jdec.Decode(&nodeInfo)
+	if err != nil {
+		return NodeInfo{}, err
+	}
 
-	if resp.StatusCode != http.StatusOK {
-		// we had an error, deserialize it and return
-		var jsonError Error
-		err := jdec.Decode(&jsonError)
-		if err != nil {
-			return AccountData{}, fmt.Errorf("error deserializing account data JSON error: %w", err)
-		}
+	return nodeInfo, nil
+}
 
-		return AccountData{}, fmt.Errorf("error during get account data: %s", jsonError.Error)
+// Syncing reports whether the full node is still catching up with the chain.
+func (c *Client) Syncing(ctx context.Context) (bool, error) {
+	path := "/syncing"
+	if c.SignMode == SignModeDirect {
+		path = "/cosmos/base/tendermint/v1beta1/syncing"
 	}
 
-	var accountData AccountData
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return false, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("error during syncing query: %w", newHTTPError(resp))
+	}
 
-	errCdc := jdec.Decode(&accountData)
-	if errCdc != nil {
-		return AccountData{}, fmt.Errorf("could not unmarshal node response: %w", errCdc)
+	var syncing struct {
+		Syncing bool `json:"syncing"`
 	}
 
-	if accountData.Result.Value.Address == "" {
-		return AccountData{}, fmt.Errorf("account with address %s is not online", address)
+	jdec := json.NewDecoder(resp.Body)
+	if err := jdec.Decode(&syncing); err != nil {
+		return false, fmt.Errorf("could not unmarshal node response: %w", err)
 	}
 
-	return accountData, nil
+	return syncing.Syncing, nil
 }
 
-// Return useful information of the full node, like the Network
-// (chain) name.
-func getNodeInfo(lcdEndpoint string) (NodeInfo, error) {
-	endpoint := fmt.Sprintf("%s/node_info", lcdEndpoint)
-	resp, err := http.Get(endpoint)
+// LatestBlockHeight returns the height of the latest block known to the full node.
+func (c *Client) LatestBlockHeight(ctx context.Context) (int64, error) {
+	path := "/blocks/latest"
+	if c.SignMode == SignModeDirect {
+		path = "/cosmos/base/tendermint/v1beta1/blocks/latest"
+	}
+
+	resp, err := c.get(ctx, path)
 	if err != nil {
-		return NodeInfo{}, err
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("error during latest block query: %w", newHTTPError(resp))
+	}
+
+	var block struct {
+		Block struct {
+			Header struct {
+				Height int64 `json:"height,string"`
+			} `json:"header"`
+		} `json:"block"`
 	}
 
-	var nodeInfo NodeInfo
 	jdec := json.NewDecoder(resp.Body)
-	err = jdec.Decode(&nodeInfo)
-	if err != nil {
-		return NodeInfo{}, err
+	if err := jdec.Decode(&block); err != nil {
+		return 0, fmt.Errorf("could not unmarshal node response: %w", err)
 	}
 
-	return nodeInfo, nil
+	return block.Block.Header.Height, nil
+}
+
+// Retrieve the account data related to the given wallet address, like
+// account number and sequence number.
+func getAccountData(lcdEndpoint, address string) (AccountData, error) {
+	return NewClient(lcdEndpoint, nil, nil).AccountData(context.Background(), address)
+}
+
+// Return useful information of the full node, like the Network
+// (chain) name.
+func getNodeInfo(lcdEndpoint string) (NodeInfo, error) {
+	return NewClient(lcdEndpoint, nil, nil).NodeInfo(context.Background())
 }
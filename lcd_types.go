@@ -1,6 +1,8 @@
 package sacco
 
 import (
+	"encoding/json"
+
 	sdkTypes "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -41,6 +43,9 @@ type NodeInfo struct {
 
 // TxResponse represents whatever data the LCD REST service returns to atomicwallet
 // after a transaction gets forwarded to it.
+// RawTx holds the JSON representation of the transaction, if the LCD included it.
+// Tx is never set when decoding a TxResponse: decode RawTx with an amino codec
+// knowing the transaction messages instead, like the one returned by NewCodec.
 type TxResponse struct {
 	Height    string                   `json:"height"`
 	TxHash    string                   `json:"txhash"`
@@ -52,7 +57,8 @@ type TxResponse struct {
 	GasWanted string                   `json:"gas_wanted,omitempty"`
 	GasUsed   string                   `json:"gas_used,omitempty"`
 	Codespace string                   `json:"codespace,omitempty"`
-	Tx        sdkTypes.Tx              `json:"tx,omitempty"`
+	Tx        sdkTypes.Tx              `json:"-"`
+	RawTx     json.RawMessage          `json:"tx,omitempty"`
 	Timestamp string                   `json:"timestamp,omitempty"`
 
 	// DEPRECATED: Remove in the next next major release in favor of using the
@@ -0,0 +1,261 @@
+package sacco
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"strings"
+	"sync"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Language identifies one of the official BIP-39 wordlists.
+type Language string
+
+// String implements the stringer interface for Language.
+func (l Language) String() string {
+	return string(l)
+}
+
+const (
+	// LanguageEnglish is the english BIP-39 wordlist.
+	LanguageEnglish Language = "english"
+
+	// LanguageItalian is the italian BIP-39 wordlist.
+	LanguageItalian Language = "italian"
+
+	// LanguageFrench is the french BIP-39 wordlist.
+	LanguageFrench Language = "french"
+
+	// LanguageSpanish is the spanish BIP-39 wordlist.
+	LanguageSpanish Language = "spanish"
+
+	// LanguageCzech is the czech BIP-39 wordlist.
+	LanguageCzech Language = "czech"
+
+	// LanguageJapanese is the japanese BIP-39 wordlist.
+	LanguageJapanese Language = "japanese"
+
+	// LanguageKorean is the korean BIP-39 wordlist.
+	LanguageKorean Language = "korean"
+
+	// LanguageChineseSimplified is the simplified chinese BIP-39 wordlist.
+	LanguageChineseSimplified Language = "chinese_simplified"
+
+	// LanguageChineseTraditional is the traditional chinese BIP-39 wordlist.
+	LanguageChineseTraditional Language = "chinese_traditional"
+)
+
+// Languages holds every Language supported by GenerateMnemonicWithOptions,
+// in the order used when looking for the wordlist of a mnemonic.
+var Languages = []Language{
+	LanguageEnglish,
+	LanguageItalian,
+	LanguageFrench,
+	LanguageSpanish,
+	LanguageCzech,
+	LanguageJapanese,
+	LanguageKorean,
+	LanguageChineseSimplified,
+	LanguageChineseTraditional,
+}
+
+// wordlist holds the words of a BIP-39 wordlist, along with the
+// reverse mapping from a (NFKD-normalized) word to its index.
+type wordlist struct {
+	words   []string
+	indexes map[string]int
+}
+
+var (
+	wordlistsOnce sync.Once
+	wordlistsMap  map[Language]wordlist
+)
+
+// getWordlist returns the wordlist associated to lang.
+func getWordlist(lang Language) (wordlist, bool) {
+	wordlistsOnce.Do(func() {
+		raw := map[Language][]string{
+			LanguageEnglish:            wordlists.English,
+			LanguageItalian:            wordlists.Italian,
+			LanguageFrench:             wordlists.French,
+			LanguageSpanish:            wordlists.Spanish,
+			LanguageCzech:              wordlists.Czech,
+			LanguageJapanese:           wordlists.Japanese,
+			LanguageKorean:             wordlists.Korean,
+			LanguageChineseSimplified:  wordlists.ChineseSimplified,
+			LanguageChineseTraditional: wordlists.ChineseTraditional,
+		}
+
+		wordlistsMap = make(map[Language]wordlist, len(raw))
+		for l, words := range raw {
+			wl := wordlist{
+				words:   words,
+				indexes: make(map[string]int, len(words)),
+			}
+
+			for i, word := range words {
+				wl.indexes[norm.NFKD.String(word)] = i
+			}
+
+			wordlistsMap[l] = wl
+		}
+	})
+
+	wl, ok := wordlistsMap[lang]
+	return wl, ok
+}
+
+// GenerateMnemonicWithOptions generates a new random mnemonic sequence made of
+// words words, taken from the lang BIP-39 wordlist.
+// words must be one of 12, 15, 18, 21 or 24.
+func GenerateMnemonicWithOptions(words int, lang Language) (string, error) {
+	if !validMnemonicLength(words) {
+		return "", ErrMnemonicLength(words)
+	}
+
+	wl, ok := getWordlist(lang)
+	if !ok {
+		return "", ErrUnknownLanguage(lang)
+	}
+
+	// each word encodes 11 bits, of which one every 33 is checksum
+	entropy := make([]byte, words*11*32/33/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+
+	return entropyToMnemonic(entropy, wl, lang), nil
+}
+
+// entropyToMnemonic encodes entropy as a sequence of words taken from wl,
+// following the BIP-39 specification.
+func entropyToMnemonic(entropy []byte, wl wordlist, lang Language) string {
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+
+	wordsNum := (len(entropy)*8 + len(entropy)/4) / 11
+	words := make([]string, wordsNum)
+
+	for i := range words {
+		words[i] = wl.words[readBits(data, i*11, 11)]
+	}
+
+	separator := " "
+	if lang == LanguageJapanese {
+		separator = "\u3000"
+	}
+
+	return strings.Join(words, separator)
+}
+
+// MnemonicLanguage returns the Language of mnemonic, or an error if mnemonic is not
+// a valid BIP-39 mnemonic in any of the supported Languages.
+// Since some wordlists share words (e.g. simplified and traditional chinese), a
+// mnemonic made only of shared words can be valid in more than one Language: in
+// that case MnemonicLanguage returns ErrAmbiguousMnemonic.
+func MnemonicLanguage(mnemonic string) (Language, error) {
+	langs, err := mnemonicLanguages(mnemonic)
+	if err != nil {
+		return "", err
+	}
+
+	if len(langs) > 1 {
+		return "", ErrAmbiguousMnemonic(langs)
+	}
+
+	return langs[0], nil
+}
+
+// IsMnemonicValid returns true whether mnemonic is a valid BIP-39 mnemonic
+// in any of the supported Languages, false otherwise.
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := mnemonicLanguages(mnemonic)
+	return err == nil
+}
+
+// mnemonicLanguages returns every Language in which mnemonic is a valid BIP-39
+// mnemonic, or an error if there's none.
+func mnemonicLanguages(mnemonic string) ([]Language, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if !validMnemonicLength(len(words)) {
+		return nil, ErrMnemonicLength(len(words))
+	}
+
+	var langs []Language
+	for _, lang := range Languages {
+		wl, _ := getWordlist(lang)
+		if mnemonicChecksumValid(words, wl) {
+			langs = append(langs, lang)
+		}
+	}
+
+	if len(langs) == 0 {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return langs, nil
+}
+
+// mnemonicChecksumValid returns true whether each of words belongs to wl, and
+// the checksum they encode matches the entropy they encode.
+func mnemonicChecksumValid(words []string, wl wordlist) bool {
+	totalBits := len(words) * 11
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+
+	data := make([]byte, (totalBits+7)/8)
+	for i, word := range words {
+		index, ok := wl.indexes[word]
+		if !ok {
+			return false
+		}
+
+		writeBits(data, i*11, 11, index)
+	}
+
+	entropy := data[:entropyBits/8]
+	checksum := sha256.Sum256(entropy)
+
+	return readBits(data, entropyBits, checksumBits) == readBits(checksum[:], 0, checksumBits)
+}
+
+// newSeed derives a BIP-39 seed from mnemonic and passphrase, both
+// normalized in NFKD form as mandated by the specification.
+func newSeed(mnemonic, passphrase string) []byte {
+	mnemonic = strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+
+	return pbkdf2.Key([]byte(mnemonic), []byte(salt), 2048, 64, sha512.New)
+}
+
+// validMnemonicLength returns true whether words is a valid BIP-39 mnemonic
+// length.
+func validMnemonicLength(words int) bool {
+	return words >= 12 && words <= 24 && words%3 == 0
+}
+
+// readBits reads count bits from data, starting at the offset-th bit.
+func readBits(data []byte, offset, count int) int {
+	value := 0
+	for i := offset; i < offset+count; i++ {
+		bit := (data[i/8] >> (7 - uint(i%8))) & 1
+		value = value<<1 | int(bit)
+	}
+
+	return value
+}
+
+// writeBits writes the count least significant bits of value in data,
+// starting at the offset-th bit.
+func writeBits(data []byte, offset, count, value int) {
+	for i := 0; i < count; i++ {
+		if (value>>(uint(count-1-i)))&1 == 1 {
+			pos := offset + i
+			data[pos/8] |= 1 << (7 - uint(pos%8))
+		}
+	}
+}
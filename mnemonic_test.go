@@ -0,0 +1,155 @@
+package sacco
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMnemonicWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		words     int
+		lang      Language
+		assertion assert.ErrorAssertionFunc
+	}{
+		{"12 english words", 12, LanguageEnglish, assert.NoError},
+		{"15 english words", 15, LanguageEnglish, assert.NoError},
+		{"18 italian words", 18, LanguageItalian, assert.NoError},
+		{"21 italian words", 21, LanguageItalian, assert.NoError},
+		{"24 italian words", 24, LanguageItalian, assert.NoError},
+		{"24 japanese words", 24, LanguageJapanese, assert.NoError},
+		{"12 spanish words", 12, LanguageSpanish, assert.NoError},
+		{"invalid number of words", 13, LanguageEnglish, assert.Error},
+		{"too many words", 27, LanguageEnglish, assert.Error},
+		{"unknown language", 12, Language("klingon"), assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateMnemonicWithOptions(tt.words, tt.lang)
+			tt.assertion(t, err)
+
+			if err == nil {
+				assert.Len(t, strings.Fields(got), tt.words)
+
+				lang, err := MnemonicLanguage(got)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.lang, lang)
+			}
+		})
+	}
+}
+
+func Test_entropyToMnemonic(t *testing.T) {
+	tests := []struct {
+		name    string
+		entropy []byte
+		lang    Language
+		want    string
+	}{
+		{
+			"english test vector, all zeroes",
+			make([]byte, 16),
+			LanguageEnglish,
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		},
+		{
+			"english test vector, 0x7f",
+			bytes.Repeat([]byte{0x7f}, 16),
+			LanguageEnglish,
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		},
+		{
+			"english test vector, 256 bits",
+			make([]byte, 32),
+			LanguageEnglish,
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		},
+		{
+			"italian, all zeroes",
+			make([]byte, 16),
+			LanguageItalian,
+			"abaco abaco abaco abaco abaco abaco abaco abaco abaco abaco abaco abete",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wl, ok := getWordlist(tt.lang)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, entropyToMnemonic(tt.entropy, wl, tt.lang))
+		})
+	}
+}
+
+func TestMnemonicLanguage(t *testing.T) {
+	tests := []struct {
+		name      string
+		mnemonic  string
+		want      Language
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"english mnemonic",
+			"final random flame cinnamon grunt hazard easily mutual resist pond solution define knife female tongue crime atom jaguar alert library best forum lesson rigid",
+			LanguageEnglish,
+			assert.NoError,
+		},
+		{
+			"italian mnemonic",
+			"sorpasso cortese narrato staffa pallido evidenza fiducia omega elfico cena stridulo nettuno",
+			LanguageItalian,
+			assert.NoError,
+		},
+		{
+			"traditional chinese mnemonic",
+			"槍 疫 黴 嘗 倆 鬧 餓 賢 槍 疫 黴 卿",
+			LanguageChineseTraditional,
+			assert.NoError,
+		},
+		{
+			"simplified chinese mnemonic",
+			"枪 疫 霉 尝 俩 闹 饿 贤 枪 疫 霉 卿",
+			LanguageChineseSimplified,
+			assert.NoError,
+		},
+		{
+			"chinese mnemonic made only of words shared by both wordlists",
+			"的 的 的 的 的 的 的 的 的 的 的 在",
+			"",
+			assert.Error,
+		},
+		{
+			"italian mnemonic with wrong checksum",
+			"abaco abaco abaco abaco abaco abaco abaco abaco abaco abaco abaco abaco",
+			"",
+			assert.Error,
+		},
+		{
+			"english words which aren't in the wordlist",
+			"these words are not a mnemonic at all and should not be valid here ok",
+			"",
+			assert.Error,
+		},
+		{
+			"empty mnemonic",
+			"",
+			"",
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MnemonicLanguage(tt.mnemonic)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsMnemonicValid_ambiguous(t *testing.T) {
+	// the same words, at the same indexes, encode the same entropy in both
+	// chinese wordlists: the mnemonic is valid even though its language isn't known
+	assert.True(t, IsMnemonicValid("的 的 的 的 的 的 的 的 的 的 的 在"))
+}
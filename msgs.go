@@ -0,0 +1,241 @@
+package sacco
+
+import "encoding/json"
+
+// Msg is a Cosmos message which can be included in a TransactionPayload.
+// Its JSON representation must match the amino JSON "value" object the LCD expects.
+type Msg interface {
+	// AminoType returns the name the message is registered with in the
+	// chain amino codec, e.g. "cosmos-sdk/MsgSend".
+	AminoType() string
+}
+
+// aminoJSON is the amino JSON representation of a registered concrete type.
+type aminoJSON struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalMsg returns the amino JSON representation of msg, ready to be included in the
+// Message field of a TransactionPayload.
+func MarshalMsg(msg Msg) (json.RawMessage, error) {
+	return json.Marshal(aminoJSON{
+		Type:  msg.AminoType(),
+		Value: msg,
+	})
+}
+
+// AddMsgs marshals each of msgs to its amino JSON representation, and appends them to
+// tx's Message.
+func (tx *TransactionPayload) AddMsgs(msgs ...Msg) error {
+	for _, msg := range msgs {
+		raw, err := MarshalMsg(msg)
+		if err != nil {
+			return err
+		}
+
+		tx.Message = append(tx.Message, raw)
+	}
+
+	return nil
+}
+
+// MsgSend is the bank module message used to send coins from an address to another.
+type MsgSend struct {
+	FromAddress string `json:"from_address"`
+	ToAddress   string `json:"to_address"`
+	Amount      Coins  `json:"amount"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgSend) AminoType() string {
+	return "cosmos-sdk/MsgSend"
+}
+
+// Input is a MsgMultiSend input.
+type Input struct {
+	Address string `json:"address"`
+	Coins   Coins  `json:"coins"`
+}
+
+// Output is a MsgMultiSend output.
+type Output struct {
+	Address string `json:"address"`
+	Coins   Coins  `json:"coins"`
+}
+
+// MsgMultiSend is the bank module message used to send coins from many inputs
+// to many outputs.
+type MsgMultiSend struct {
+	Inputs  []Input  `json:"inputs"`
+	Outputs []Output `json:"outputs"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgMultiSend) AminoType() string {
+	return "cosmos-sdk/MsgMultiSend"
+}
+
+// MsgDelegate is the staking module message used to delegate coins to a validator.
+type MsgDelegate struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+	Amount           Coin   `json:"amount"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgDelegate) AminoType() string {
+	return "cosmos-sdk/MsgDelegate"
+}
+
+// MsgUndelegate is the staking module message used to undelegate coins from a validator.
+type MsgUndelegate struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+	Amount           Coin   `json:"amount"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgUndelegate) AminoType() string {
+	return "cosmos-sdk/MsgUndelegate"
+}
+
+// MsgBeginRedelegate is the staking module message used to move delegated coins
+// from a validator to another.
+type MsgBeginRedelegate struct {
+	DelegatorAddress    string `json:"delegator_address"`
+	ValidatorSrcAddress string `json:"validator_src_address"`
+	ValidatorDstAddress string `json:"validator_dst_address"`
+	Amount              Coin   `json:"amount"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgBeginRedelegate) AminoType() string {
+	return "cosmos-sdk/MsgBeginRedelegate"
+}
+
+// MsgWithdrawDelegatorReward is the distribution module message used to withdraw
+// the rewards of a delegation.
+type MsgWithdrawDelegatorReward struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgWithdrawDelegatorReward) AminoType() string {
+	return "cosmos-sdk/MsgWithdrawDelegationReward"
+}
+
+// ProposalContent is the content of a governance proposal.
+type ProposalContent interface {
+	// AminoType returns the name the content is registered with in the
+	// chain amino codec, e.g. "cosmos-sdk/TextProposal".
+	AminoType() string
+}
+
+// TextProposal is a governance proposal made only of a title and a description.
+type TextProposal struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// AminoType implements the ProposalContent interface.
+func (TextProposal) AminoType() string {
+	return "cosmos-sdk/TextProposal"
+}
+
+// MsgSubmitProposal is the governance module message used to submit a new proposal.
+type MsgSubmitProposal struct {
+	Content        ProposalContent
+	InitialDeposit Coins
+	Proposer       string
+}
+
+// AminoType implements the Msg interface.
+func (MsgSubmitProposal) AminoType() string {
+	return "cosmos-sdk/MsgSubmitProposal"
+}
+
+// MarshalJSON implements the json.Marshaler interface for MsgSubmitProposal,
+// encoding Content as an amino interface.
+func (msg MsgSubmitProposal) MarshalJSON() ([]byte, error) {
+	if msg.Content == nil {
+		return nil, ErrMissingProposalContent
+	}
+
+	return json.Marshal(struct {
+		Content        aminoJSON `json:"content"`
+		InitialDeposit Coins     `json:"initial_deposit"`
+		Proposer       string    `json:"proposer"`
+	}{
+		Content: aminoJSON{
+			Type:  msg.Content.AminoType(),
+			Value: msg.Content,
+		},
+		InitialDeposit: msg.InitialDeposit,
+		Proposer:       msg.Proposer,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for MsgSubmitProposal.
+// Only TextProposal contents can be decoded.
+func (msg *MsgSubmitProposal) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Content struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+		} `json:"content"`
+		InitialDeposit Coins  `json:"initial_deposit"`
+		Proposer       string `json:"proposer"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var content TextProposal
+	if raw.Content.Type != content.AminoType() {
+		return ErrUnsupportedProposalContent(raw.Content.Type)
+	}
+
+	if err := json.Unmarshal(raw.Content.Value, &content); err != nil {
+		return err
+	}
+
+	*msg = MsgSubmitProposal{
+		Content:        content,
+		InitialDeposit: raw.InitialDeposit,
+		Proposer:       raw.Proposer,
+	}
+
+	return nil
+}
+
+// VoteOption is the option chosen by a voter on a governance proposal.
+type VoteOption string
+
+const (
+	// OptionYes is a vote in favor of a proposal.
+	OptionYes VoteOption = "Yes"
+
+	// OptionAbstain is an abstention from voting on a proposal.
+	OptionAbstain VoteOption = "Abstain"
+
+	// OptionNo is a vote against a proposal.
+	OptionNo VoteOption = "No"
+
+	// OptionNoWithVeto is a vote against a proposal, with veto.
+	OptionNoWithVeto VoteOption = "NoWithVeto"
+)
+
+// MsgVote is the governance module message used to vote on a proposal.
+type MsgVote struct {
+	ProposalID uint64     `json:"proposal_id,string"`
+	Voter      string     `json:"voter"`
+	Option     VoteOption `json:"option"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgVote) AminoType() string {
+	return "cosmos-sdk/MsgVote"
+}
@@ -0,0 +1,185 @@
+package sacco
+
+// This file contains the messages of the commercio.network chain modules.
+// Their amino JSON representation follows the one used by commercionetwork v2.1.
+
+// DocumentMetadataSchema identifies the schema a document metadata complies to.
+type DocumentMetadataSchema struct {
+	URI     string `json:"uri"`
+	Version string `json:"version"`
+}
+
+// DocumentMetadata holds the metadata associated to a shared document.
+// Either SchemaType or Schema must be set.
+type DocumentMetadata struct {
+	ContentURI string                  `json:"content_uri"`
+	SchemaType string                  `json:"schema_type,omitempty"`
+	Schema     *DocumentMetadataSchema `json:"schema,omitempty"`
+}
+
+// DocumentChecksum holds the checksum of a shared document content, along
+// with the algorithm used to compute it.
+type DocumentChecksum struct {
+	Value     string `json:"value"`
+	Algorithm string `json:"algorithm"`
+}
+
+// DocumentEncryptionKey is the encryption key of a document, encrypted for a
+// single recipient.
+type DocumentEncryptionKey struct {
+	Recipient string `json:"recipient"`
+	Value     string `json:"value"`
+}
+
+// DocumentEncryptionData describes which fields of a shared document have been
+// encrypted, and the keys used to do so.
+type DocumentEncryptionData struct {
+	Keys          []DocumentEncryptionKey `json:"keys"`
+	EncryptedData []string                `json:"encrypted_data"`
+}
+
+// DocumentDoSign holds the informations needed to digitally sign a shared document.
+type DocumentDoSign struct {
+	StorageURI         string   `json:"storage_uri"`
+	SignerInstance     string   `json:"signer_instance"`
+	SdnData            []string `json:"sdn_data,omitempty"`
+	VcrID              string   `json:"vcr_id"`
+	CertificateProfile string   `json:"certificate_profile"`
+}
+
+// MsgShareDocument is the docs module message used to share a document with a
+// set of recipients.
+type MsgShareDocument struct {
+	Sender         string                  `json:"sender"`
+	Recipients     []string                `json:"recipients"`
+	UUID           string                  `json:"uuid"`
+	Metadata       DocumentMetadata        `json:"metadata"`
+	ContentURI     string                  `json:"content_uri,omitempty"`
+	Checksum       *DocumentChecksum       `json:"checksum,omitempty"`
+	EncryptionData *DocumentEncryptionData `json:"encryption_data,omitempty"`
+	DoSign         *DocumentDoSign         `json:"do_sign,omitempty"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgShareDocument) AminoType() string {
+	return "commercio/MsgShareDocument"
+}
+
+// MsgSendDocumentReceipt is the docs module message used by a document recipient
+// to notify the sender that the document has been received.
+type MsgSendDocumentReceipt struct {
+	UUID         string `json:"uuid"`
+	Sender       string `json:"sender"`
+	Recipient    string `json:"recipient"`
+	TxHash       string `json:"tx_hash"`
+	DocumentUUID string `json:"document_uuid"`
+	Proof        string `json:"proof,omitempty"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgSendDocumentReceipt) AminoType() string {
+	return "commercio/MsgSendDocumentReceipt"
+}
+
+// DidPubKey is a public key listed in a DID document.
+type DidPubKey struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	Controller   string `json:"controller"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// DidProof is the proof of ownership of a DID document.
+// Created must be formatted as a RFC 3339 timestamp.
+type DidProof struct {
+	Type               string `json:"type"`
+	Created            string `json:"created"`
+	ProofPurpose       string `json:"proofPurpose"`
+	Controller         string `json:"controller"`
+	VerificationMethod string `json:"verificationMethod"`
+	SignatureValue     string `json:"signatureValue"`
+}
+
+// DidService is a service endpoint listed in a DID document.
+type DidService struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// MsgSetIdentity is the id module message used to create or update the DID document
+// associated to an address.
+type MsgSetIdentity struct {
+	Context string       `json:"@context"`
+	ID      string       `json:"id"`
+	PubKeys []DidPubKey  `json:"publicKey"`
+	Proof   DidProof     `json:"proof"`
+	Service []DidService `json:"service,omitempty"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgSetIdentity) AminoType() string {
+	return "commercio/MsgSetIdentity"
+}
+
+// MsgRequestDidPowerUp is the id module message used to request a deposit of tokens
+// on a private pairwise DID.
+type MsgRequestDidPowerUp struct {
+	Claimant string `json:"claimant"`
+	Amount   Coins  `json:"amount"`
+	Proof    string `json:"proof"`
+	ID       string `json:"id"`
+	ProofKey string `json:"proof_key"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgRequestDidPowerUp) AminoType() string {
+	return "commercio/MsgRequestDidPowerUp"
+}
+
+// MsgInviteUser is the memberships module message used to invite a user to buy
+// a membership.
+type MsgInviteUser struct {
+	Recipient string `json:"receiver"`
+	Sender    string `json:"sender"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgInviteUser) AminoType() string {
+	return "commercio/MsgInviteUser"
+}
+
+// MsgBuyMembership is the memberships module message used to buy a membership,
+// e.g. "bronze", "silver", "gold" or "black".
+type MsgBuyMembership struct {
+	MembershipType string `json:"membership_type"`
+	Buyer          string `json:"buyer"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgBuyMembership) AminoType() string {
+	return "commercio/MsgBuyMembership"
+}
+
+// MsgOpenCdp is the mint module message used to open a collateralized debt position.
+type MsgOpenCdp struct {
+	Depositor       string `json:"depositor"`
+	DepositedAmount Coins  `json:"deposit_amount"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgOpenCdp) AminoType() string {
+	return "commercio/MsgOpenCdp"
+}
+
+// MsgCloseCdp is the mint module message used to close the collateralized debt position
+// opened by Signer at Timestamp.
+type MsgCloseCdp struct {
+	Signer    string `json:"signer"`
+	Timestamp int64  `json:"cdp_timestamp,string"`
+}
+
+// AminoType implements the Msg interface.
+func (MsgCloseCdp) AminoType() string {
+	return "commercio/MsgCloseCdp"
+}
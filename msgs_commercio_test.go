@@ -0,0 +1,160 @@
+package sacco
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	sdkTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fixtures in testdata/commercio are golden files holding the sorted amino JSON
+// sacco produces for each message, i.e. the bytes it signs. They were written by hand
+// after the commercionetwork message definitions and weren't recorded from a chain
+// build: they catch regressions in the encoding, not mismatches with the chain codec.
+
+func TestMarshalMsg_commercio(t *testing.T) {
+	const (
+		sender    = "did:com:1sfjela2snk9rmmcfh773gm50476w0ur5pmwuak"
+		recipient = "did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm9"
+	)
+
+	tests := []struct {
+		name    string
+		msg     Msg
+		fixture string
+	}{
+		{
+			"MsgShareDocument with only required fields",
+			MsgShareDocument{
+				Sender:     sender,
+				Recipients: []string{recipient},
+				UUID:       "d83422c6-6e79-4a99-9767-fcae46dfa371",
+				Metadata: DocumentMetadata{
+					ContentURI: "https://example.com/document/metadata",
+					SchemaType: "uni-sincro",
+				},
+			},
+			"MsgShareDocument_required",
+		},
+		{
+			"MsgShareDocument with all the fields",
+			MsgShareDocument{
+				Sender:     sender,
+				Recipients: []string{recipient},
+				UUID:       "d83422c6-6e79-4a99-9767-fcae46dfa371",
+				Metadata: DocumentMetadata{
+					ContentURI: "https://example.com/document/metadata",
+					Schema: &DocumentMetadataSchema{
+						URI:     "https://example.com/document/metadata/schema",
+						Version: "1.0.0",
+					},
+				},
+				ContentURI: "https://example.com/document",
+				Checksum: &DocumentChecksum{
+					Value:     "93dfcaf3d923ec47edb8580667473987",
+					Algorithm: "md5",
+				},
+				EncryptionData: &DocumentEncryptionData{
+					Keys: []DocumentEncryptionKey{
+						{Recipient: recipient, Value: "6F7468657220656E6372797074696F6E206B6579"},
+					},
+					EncryptedData: []string{"content_uri"},
+				},
+				DoSign: &DocumentDoSign{
+					StorageURI:         "http://www.commercio.network",
+					SignerInstance:     "did:com:1cc65t29yuwuc32ep2h9uqhnwrregfq230lf2rj",
+					SdnData:            []string{"common_name", "surname"},
+					VcrID:              "xxxxx",
+					CertificateProfile: "xxxxx",
+				},
+			},
+			"MsgShareDocument_full",
+		},
+		{
+			"MsgSendDocumentReceipt",
+			MsgSendDocumentReceipt{
+				UUID:         "8db853ac-5265-4da6-a07a-c52ac8099385",
+				Sender:       recipient,
+				Recipient:    sender,
+				TxHash:       "RNDV8B9E8ZXJB8Z36L0KZCEWW5E5E34DQ7HXXMWS9XCUQPG40AQ1ABWTCGWBK5IO",
+				DocumentUUID: "d83422c6-6e79-4a99-9767-fcae46dfa371",
+				Proof:        "proof",
+			},
+			"MsgSendDocumentReceipt",
+		},
+		{
+			"MsgSetIdentity",
+			MsgSetIdentity{
+				Context: "https://www.w3.org/ns/did/v1",
+				ID:      sender,
+				PubKeys: []DidPubKey{
+					{
+						ID:           sender + "#keys-1",
+						Type:         "RsaVerificationKey2018",
+						Controller:   sender,
+						PublicKeyPem: "-----BEGIN PUBLIC KEY-----",
+					},
+				},
+				Proof: DidProof{
+					Type:               "EcdsaSecp256k1VerificationKey2019",
+					Created:            "2020-03-04T10:11:12Z",
+					ProofPurpose:       "authentication",
+					Controller:         sender,
+					VerificationMethod: "did:com:pub1addwnpepqt6lnn5v0c3rys49v5v9f4kvcchehnu7kyg7pzz4myxnhnmm0u7pk4cgktm",
+					SignatureValue:     "nIgRvObXlOLh8vy/oWz/oj9pRmUCYNQQE1/sOn9pYwhvSKT0rzqSLuc4u4Q8OgsXo3dGfgTNNQ/Exy9IEPKyGg==",
+				},
+			},
+			"MsgSetIdentity",
+		},
+		{
+			"MsgRequestDidPowerUp",
+			MsgRequestDidPowerUp{
+				Claimant: sender,
+				Amount:   Coins{{Denom: "ucommercio", Amount: "1000"}},
+				Proof:    "proof",
+				ID:       "c510755c-c27d-4348-bf4c-f6050fc6935c",
+				ProofKey: "key",
+			},
+			"MsgRequestDidPowerUp",
+		},
+		{
+			"MsgInviteUser",
+			MsgInviteUser{Recipient: recipient, Sender: sender},
+			"MsgInviteUser",
+		},
+		{
+			"MsgBuyMembership",
+			MsgBuyMembership{MembershipType: "gold", Buyer: recipient},
+			"MsgBuyMembership",
+		},
+		{
+			"MsgOpenCdp",
+			MsgOpenCdp{Depositor: sender, DepositedAmount: Coins{{Denom: "ucommercio", Amount: "100"}}},
+			"MsgOpenCdp",
+		},
+		{
+			"MsgCloseCdp",
+			MsgCloseCdp{Signer: sender, Timestamp: 1583316672},
+			"MsgCloseCdp",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := ioutil.ReadFile(filepath.Join("testdata", "commercio", tt.fixture+".json"))
+			require.NoError(t, err)
+
+			got, err := MarshalMsg(tt.msg)
+			require.NoError(t, err)
+
+			// messages are signed in their sorted JSON form, hence they must match
+			// the fixtures byte by byte
+			sorted, err := sdkTypes.SortJSON(got)
+			require.NoError(t, err)
+			assert.Equal(t, string(bytes.TrimSpace(want)), string(sorted))
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package sacco
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// ProtoMsg is a Msg which can be included in the protobuf transactions of Cosmos SDK
+// v0.40+ chains, signed with SignModeDirect.
+type ProtoMsg interface {
+	Msg
+
+	// ProtoTypeURL returns the type URL the message is packed into a
+	// google.protobuf.Any with, e.g. "/cosmos.bank.v1beta1.MsgSend".
+	ProtoTypeURL() string
+
+	// MarshalProto returns the protobuf encoding of the message.
+	MarshalProto() ([]byte, error)
+}
+
+// ProtoProposalContent is a ProposalContent which can be included in a protobuf
+// MsgSubmitProposal.
+type ProtoProposalContent interface {
+	ProposalContent
+
+	// ProtoTypeURL returns the type URL the content is packed into a
+	// google.protobuf.Any with, e.g. "/cosmos.gov.v1beta1.TextProposal".
+	ProtoTypeURL() string
+
+	// MarshalProto returns the protobuf encoding of the content.
+	MarshalProto() ([]byte, error)
+}
+
+var (
+	protoMsgsMu sync.RWMutex
+
+	// protoMsgs holds the constructors of the ProtoMsgs known to sacco, indexed
+	// by amino type.
+	protoMsgs = map[string]func() ProtoMsg{}
+)
+
+func init() {
+	RegisterProtoMsg(func() ProtoMsg { return &MsgSend{} })
+	RegisterProtoMsg(func() ProtoMsg { return &MsgMultiSend{} })
+	RegisterProtoMsg(func() ProtoMsg { return &MsgDelegate{} })
+	RegisterProtoMsg(func() ProtoMsg { return &MsgUndelegate{} })
+	RegisterProtoMsg(func() ProtoMsg { return &MsgBeginRedelegate{} })
+	RegisterProtoMsg(func() ProtoMsg { return &MsgWithdrawDelegatorReward{} })
+	RegisterProtoMsg(func() ProtoMsg { return &MsgSubmitProposal{} })
+	RegisterProtoMsg(func() ProtoMsg { return &MsgVote{} })
+}
+
+// RegisterProtoMsg makes the ProtoMsg returned by newMsg known to SignDirect, so that
+// amino JSON messages of its type included in a TransactionPayload can be encoded
+// to protobuf.
+// newMsg must return a pointer to a new, empty message each time it's called.
+// Chain-specific messages must be registered before signing any transaction
+// including them.
+func RegisterProtoMsg(newMsg func() ProtoMsg) {
+	protoMsgsMu.Lock()
+	defer protoMsgsMu.Unlock()
+
+	protoMsgs[newMsg().AminoType()] = newMsg
+}
+
+// unmarshalProtoMsg decodes the amino JSON message raw into the ProtoMsg registered
+// for its type.
+func unmarshalProtoMsg(raw json.RawMessage) (ProtoMsg, error) {
+	var msgJSON struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	if err := json.Unmarshal(raw, &msgJSON); err != nil {
+		return nil, err
+	}
+
+	protoMsgsMu.RLock()
+	newMsg, ok := protoMsgs[msgJSON.Type]
+	protoMsgsMu.RUnlock()
+
+	if !ok {
+		return nil, ErrUnsupportedProtoMsg(msgJSON.Type)
+	}
+
+	msg := newMsg()
+	if err := json.Unmarshal(msgJSON.Value, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgSend) ProtoTypeURL() string {
+	return "/cosmos.bank.v1beta1.MsgSend"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+func (msg MsgSend) MarshalProto() ([]byte, error) {
+	var b protoBuffer
+	b.String(1, msg.FromAddress)
+	b.String(2, msg.ToAddress)
+	b.Coins(3, msg.Amount)
+
+	return b.Bytes(), nil
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgMultiSend) ProtoTypeURL() string {
+	return "/cosmos.bank.v1beta1.MsgMultiSend"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+func (msg MsgMultiSend) MarshalProto() ([]byte, error) {
+	var b protoBuffer
+
+	for _, input := range msg.Inputs {
+		var ib protoBuffer
+		ib.String(1, input.Address)
+		ib.Coins(2, input.Coins)
+
+		b.Message(1, ib.Bytes())
+	}
+
+	for _, output := range msg.Outputs {
+		var ob protoBuffer
+		ob.String(1, output.Address)
+		ob.Coins(2, output.Coins)
+
+		b.Message(2, ob.Bytes())
+	}
+
+	return b.Bytes(), nil
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgDelegate) ProtoTypeURL() string {
+	return "/cosmos.staking.v1beta1.MsgDelegate"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+func (msg MsgDelegate) MarshalProto() ([]byte, error) {
+	var b protoBuffer
+	b.String(1, msg.DelegatorAddress)
+	b.String(2, msg.ValidatorAddress)
+	b.Message(3, protoCoin(msg.Amount))
+
+	return b.Bytes(), nil
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgUndelegate) ProtoTypeURL() string {
+	return "/cosmos.staking.v1beta1.MsgUndelegate"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+func (msg MsgUndelegate) MarshalProto() ([]byte, error) {
+	var b protoBuffer
+	b.String(1, msg.DelegatorAddress)
+	b.String(2, msg.ValidatorAddress)
+	b.Message(3, protoCoin(msg.Amount))
+
+	return b.Bytes(), nil
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgBeginRedelegate) ProtoTypeURL() string {
+	return "/cosmos.staking.v1beta1.MsgBeginRedelegate"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+func (msg MsgBeginRedelegate) MarshalProto() ([]byte, error) {
+	var b protoBuffer
+	b.String(1, msg.DelegatorAddress)
+	b.String(2, msg.ValidatorSrcAddress)
+	b.String(3, msg.ValidatorDstAddress)
+	b.Message(4, protoCoin(msg.Amount))
+
+	return b.Bytes(), nil
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgWithdrawDelegatorReward) ProtoTypeURL() string {
+	return "/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+func (msg MsgWithdrawDelegatorReward) MarshalProto() ([]byte, error) {
+	var b protoBuffer
+	b.String(1, msg.DelegatorAddress)
+	b.String(2, msg.ValidatorAddress)
+
+	return b.Bytes(), nil
+}
+
+// ProtoTypeURL implements the ProtoProposalContent interface.
+func (TextProposal) ProtoTypeURL() string {
+	return "/cosmos.gov.v1beta1.TextProposal"
+}
+
+// MarshalProto implements the ProtoProposalContent interface.
+func (p TextProposal) MarshalProto() ([]byte, error) {
+	var b protoBuffer
+	b.String(1, p.Title)
+	b.String(2, p.Description)
+
+	return b.Bytes(), nil
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgSubmitProposal) ProtoTypeURL() string {
+	return "/cosmos.gov.v1beta1.MsgSubmitProposal"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+// msg Content must be a ProtoProposalContent.
+func (msg MsgSubmitProposal) MarshalProto() ([]byte, error) {
+	if msg.Content == nil {
+		return nil, ErrMissingProposalContent
+	}
+
+	content, ok := msg.Content.(ProtoProposalContent)
+	if !ok {
+		return nil, ErrUnsupportedProtoMsg(msg.Content.AminoType())
+	}
+
+	contentBytes, err := content.MarshalProto()
+	if err != nil {
+		return nil, err
+	}
+
+	var b protoBuffer
+	b.Message(1, protoAny(content.ProtoTypeURL(), contentBytes))
+	b.Coins(2, msg.InitialDeposit)
+	b.String(3, msg.Proposer)
+
+	return b.Bytes(), nil
+}
+
+// protoVoteOptions maps each VoteOption to its cosmos.gov.v1beta1.VoteOption value.
+var protoVoteOptions = map[VoteOption]uint64{
+	OptionYes:        1,
+	OptionAbstain:    2,
+	OptionNo:         3,
+	OptionNoWithVeto: 4,
+}
+
+// ProtoTypeURL implements the ProtoMsg interface.
+func (MsgVote) ProtoTypeURL() string {
+	return "/cosmos.gov.v1beta1.MsgVote"
+}
+
+// MarshalProto implements the ProtoMsg interface.
+func (msg MsgVote) MarshalProto() ([]byte, error) {
+	option, ok := protoVoteOptions[msg.Option]
+	if !ok {
+		return nil, ErrInvalidVoteOption(msg.Option)
+	}
+
+	var b protoBuffer
+	b.Uint64(1, msg.ProposalID)
+	b.String(2, msg.Voter)
+	b.Uint64(3, option)
+
+	return b.Bytes(), nil
+}
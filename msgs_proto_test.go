@@ -0,0 +1,130 @@
+package sacco
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoMsg_MarshalProto(t *testing.T) {
+	validator := "cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk"
+	coin := Coin{Denom: "uatom", Amount: "10"}
+
+	tests := []struct {
+		name      string
+		msg       ProtoMsg
+		typeURL   string
+		wantHex   string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"MsgSend",
+			MsgSend{FromAddress: testAccAddress, ToAddress: testAccAddress, Amount: Coins{coin}},
+			"/cosmos.bank.v1beta1.MsgSend",
+			"0a2d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b7739122d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b77391a0b0a057561746f6d12023130",
+			assert.NoError,
+		},
+		{
+			"MsgDelegate",
+			MsgDelegate{DelegatorAddress: testAccAddress, ValidatorAddress: validator, Amount: coin},
+			"/cosmos.staking.v1beta1.MsgDelegate",
+			"0a2d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b77391234636f736d6f7376616c6f7065723168757964656576707a3337736439736e6b67756c363037306d73747570756b77326d6a727a6b1a0b0a057561746f6d12023130",
+			assert.NoError,
+		},
+		{
+			"MsgWithdrawDelegatorReward",
+			MsgWithdrawDelegatorReward{DelegatorAddress: testAccAddress, ValidatorAddress: validator},
+			"/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward",
+			"0a2d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b77391234636f736d6f7376616c6f7065723168757964656576707a3337736439736e6b67756c363037306d73747570756b77326d6a727a6b",
+			assert.NoError,
+		},
+		{
+			"MsgSubmitProposal",
+			MsgSubmitProposal{
+				Content:        TextProposal{Title: "title", Description: "description"},
+				InitialDeposit: Coins{coin},
+				Proposer:       testAccAddress,
+			},
+			"/cosmos.gov.v1beta1.MsgSubmitProposal",
+			"0a380a202f636f736d6f732e676f762e763162657461312e5465787450726f706f73616c12140a057469746c65120b6465736372697074696f6e120b0a057561746f6d120231301a2d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b7739",
+			assert.NoError,
+		},
+		{
+			"MsgVote",
+			MsgVote{ProposalID: 1, Voter: testAccAddress, Option: OptionYes},
+			"/cosmos.gov.v1beta1.MsgVote",
+			"0801122d636f736d6f733168757964656576707a3337736439736e6b67756c363037306d73747570756b773030786b77391801",
+			assert.NoError,
+		},
+		{
+			"MsgVote with an invalid option",
+			MsgVote{ProposalID: 1, Voter: testAccAddress, Option: "Maybe"},
+			"/cosmos.gov.v1beta1.MsgVote",
+			"",
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.msg.MarshalProto()
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.wantHex, hex.EncodeToString(got))
+			assert.Equal(t, tt.typeURL, tt.msg.ProtoTypeURL())
+		})
+	}
+}
+
+func TestUnmarshalProtoMsg(t *testing.T) {
+	tx := TransactionPayload{}
+	require.NoError(t, tx.AddMsgs(
+		MsgSend{FromAddress: testAccAddress, ToAddress: testAccAddress, Amount: Coins{{Denom: "uatom", Amount: "10"}}},
+		MsgSubmitProposal{
+			Content:        TextProposal{Title: "title", Description: "description"},
+			InitialDeposit: Coins{{Denom: "uatom", Amount: "10"}},
+			Proposer:       testAccAddress,
+		},
+		MsgInviteUser{Recipient: testAccAddress, Sender: testAccAddress},
+	))
+
+	tests := []struct {
+		name      string
+		raw       json.RawMessage
+		want      ProtoMsg
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"MsgSend",
+			tx.Message[0],
+			&MsgSend{FromAddress: testAccAddress, ToAddress: testAccAddress, Amount: Coins{{Denom: "uatom", Amount: "10"}}},
+			assert.NoError,
+		},
+		{
+			"MsgSubmitProposal",
+			tx.Message[1],
+			&MsgSubmitProposal{
+				Content:        TextProposal{Title: "title", Description: "description"},
+				InitialDeposit: Coins{{Denom: "uatom", Amount: "10"}},
+				Proposer:       testAccAddress,
+			},
+			assert.NoError,
+		},
+		{
+			"message without protobuf encoding",
+			tx.Message[2],
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unmarshalProtoMsg(tt.raw)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
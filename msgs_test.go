@@ -0,0 +1,144 @@
+package sacco
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAccAddress = "cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9"
+	testValAddress = "cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk"
+)
+
+func TestMarshalMsg(t *testing.T) {
+	coins := Coins{{Denom: "uatom", Amount: "10"}}
+
+	// fixtures have been generated with the cosmos-sdk v0.38 amino codec
+	tests := []struct {
+		name     string
+		msg      Msg
+		wantJSON string
+	}{
+		{
+			"MsgSend",
+			MsgSend{FromAddress: testAccAddress, ToAddress: testAccAddress, Amount: coins},
+			`{"type":"cosmos-sdk/MsgSend","value":{"amount":[{"amount":"10","denom":"uatom"}],"from_address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","to_address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9"}}`,
+		},
+		{
+			"MsgMultiSend",
+			MsgMultiSend{
+				Inputs:  []Input{{Address: testAccAddress, Coins: coins}},
+				Outputs: []Output{{Address: testAccAddress, Coins: coins}},
+			},
+			`{"type":"cosmos-sdk/MsgMultiSend","value":{"inputs":[{"address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","coins":[{"amount":"10","denom":"uatom"}]}],"outputs":[{"address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","coins":[{"amount":"10","denom":"uatom"}]}]}}`,
+		},
+		{
+			"MsgDelegate",
+			MsgDelegate{DelegatorAddress: testAccAddress, ValidatorAddress: testValAddress, Amount: coins[0]},
+			`{"type":"cosmos-sdk/MsgDelegate","value":{"amount":{"amount":"10","denom":"uatom"},"delegator_address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","validator_address":"cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk"}}`,
+		},
+		{
+			"MsgUndelegate",
+			MsgUndelegate{DelegatorAddress: testAccAddress, ValidatorAddress: testValAddress, Amount: coins[0]},
+			`{"type":"cosmos-sdk/MsgUndelegate","value":{"amount":{"amount":"10","denom":"uatom"},"delegator_address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","validator_address":"cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk"}}`,
+		},
+		{
+			"MsgBeginRedelegate",
+			MsgBeginRedelegate{
+				DelegatorAddress:    testAccAddress,
+				ValidatorSrcAddress: testValAddress,
+				ValidatorDstAddress: testValAddress,
+				Amount:              coins[0],
+			},
+			`{"type":"cosmos-sdk/MsgBeginRedelegate","value":{"amount":{"amount":"10","denom":"uatom"},"delegator_address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","validator_dst_address":"cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk","validator_src_address":"cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk"}}`,
+		},
+		{
+			"MsgWithdrawDelegatorReward",
+			MsgWithdrawDelegatorReward{DelegatorAddress: testAccAddress, ValidatorAddress: testValAddress},
+			`{"type":"cosmos-sdk/MsgWithdrawDelegationReward","value":{"delegator_address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","validator_address":"cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk"}}`,
+		},
+		{
+			"MsgSubmitProposal",
+			MsgSubmitProposal{
+				Content:        TextProposal{Title: "t", Description: "d"},
+				InitialDeposit: coins,
+				Proposer:       testAccAddress,
+			},
+			`{"type":"cosmos-sdk/MsgSubmitProposal","value":{"content":{"type":"cosmos-sdk/TextProposal","value":{"description":"d","title":"t"}},"initial_deposit":[{"amount":"10","denom":"uatom"}],"proposer":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9"}}`,
+		},
+		{
+			"MsgVote",
+			MsgVote{ProposalID: 3, Voter: testAccAddress, Option: OptionNoWithVeto},
+			`{"type":"cosmos-sdk/MsgVote","value":{"option":"NoWithVeto","proposal_id":"3","voter":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalMsg(tt.msg)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.wantJSON, string(got))
+		})
+	}
+}
+
+func TestMsgSubmitProposal_MarshalJSON_missingContent(t *testing.T) {
+	_, err := MarshalMsg(MsgSubmitProposal{Proposer: testAccAddress})
+	assert.True(t, errors.Is(err, ErrMissingProposalContent))
+
+	_, err = MsgSubmitProposal{Proposer: testAccAddress}.MarshalProto()
+	assert.Equal(t, ErrMissingProposalContent, err)
+}
+
+func TestMsgSubmitProposal_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		json      string
+		want      MsgSubmitProposal
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"text proposal",
+			`{"content":{"type":"cosmos-sdk/TextProposal","value":{"description":"d","title":"t"}},"initial_deposit":[{"amount":"10","denom":"uatom"}],"proposer":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9"}`,
+			MsgSubmitProposal{
+				Content:        TextProposal{Title: "t", Description: "d"},
+				InitialDeposit: Coins{{Denom: "uatom", Amount: "10"}},
+				Proposer:       testAccAddress,
+			},
+			assert.NoError,
+		},
+		{
+			"unsupported content type",
+			`{"content":{"type":"cosmos-sdk/ParameterChangeProposal","value":{"description":"d","title":"t","changes":[]}},"initial_deposit":[],"proposer":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9"}`,
+			MsgSubmitProposal{},
+			func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.Equal(t, ErrUnsupportedProposalContent("cosmos-sdk/ParameterChangeProposal"), err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got MsgSubmitProposal
+			tt.assertion(t, json.Unmarshal([]byte(tt.json), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTransactionPayload_AddMsgs(t *testing.T) {
+	tx := TransactionPayload{
+		Message: []json.RawMessage{
+			json.RawMessage(`{"type":"cosmos-sdk/MsgWithdrawDelegationReward","value":{"delegator_address":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9","validator_address":"cosmosvaloper1huydeevpz37sd9snkgul6070mstupukw2mjrzk"}}`),
+		},
+	}
+
+	err := tx.AddMsgs(
+		MsgSend{FromAddress: testAccAddress, ToAddress: testAccAddress, Amount: Coins{{Denom: "uatom", Amount: "10"}}},
+		MsgVote{ProposalID: 1, Voter: testAccAddress, Option: OptionYes},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, tx.Message, 3)
+	assert.JSONEq(t, `{"type":"cosmos-sdk/MsgVote","value":{"option":"Yes","proposal_id":"1","voter":"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9"}}`, string(tx.Message[2]))
+}
@@ -0,0 +1,199 @@
+package sacco
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// multisigPubKeyType is the amino name of k-of-n threshold multisig public keys.
+const multisigPubKeyType = multisig.PubKeyMultisigThresholdAminoRoute
+
+// multisigPubKeyValue is the amino JSON "value" object of a multisig public key.
+type multisigPubKeyValue struct {
+	Threshold uint        `json:"threshold,string"`
+	PubKeys   []SigPubKey `json:"pubkeys"`
+}
+
+// NewMultisigPubKey returns a k-of-n threshold multisig public key, which requires
+// threshold signatures out of pubKeys.
+// The order of pubKeys is part of the key: the same keys in a different order yield
+// a different address.
+func NewMultisigPubKey(threshold int, pubKeys ...SigPubKey) (SigPubKey, error) {
+	if threshold <= 0 || threshold > len(pubKeys) {
+		return SigPubKey{}, ErrInvalidMultisigThreshold(threshold, len(pubKeys))
+	}
+
+	spk := SigPubKey{
+		Type:      multisigPubKeyType,
+		Threshold: uint(threshold),
+		PubKeys:   append([]SigPubKey{}, pubKeys...),
+	}
+
+	if _, err := spk.tmPubKey(); err != nil {
+		return SigPubKey{}, err
+	}
+
+	return spk, nil
+}
+
+// Address returns the bech32 address associated to spk, with the given human-readable part.
+func (spk SigPubKey) Address(hrp string) (string, error) {
+	pubKey, err := spk.tmPubKey()
+	if err != nil {
+		return "", err
+	}
+
+	return bech32.ConvertAndEncode(hrp, pubKey.Address().Bytes())
+}
+
+// tmPubKey returns the Tendermint public key described by spk.
+func (spk SigPubKey) tmPubKey() (crypto.PubKey, error) {
+	if spk.Type != multisigPubKeyType {
+		return decodeSigPubKey(spk)
+	}
+
+	if spk.Threshold == 0 || int(spk.Threshold) > len(spk.PubKeys) {
+		return nil, ErrInvalidMultisigThreshold(int(spk.Threshold), len(spk.PubKeys))
+	}
+
+	pubKeys := make([]crypto.PubKey, len(spk.PubKeys))
+	for i, pk := range spk.PubKeys {
+		pubKey, err := pk.tmPubKey()
+		if err != nil {
+			return nil, err
+		}
+
+		pubKeys[i] = pubKey
+	}
+
+	return multisig.PubKeyMultisigThreshold{
+		K:       spk.Threshold,
+		PubKeys: pubKeys,
+	}, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for SigPubKey, encoding
+// multisig public keys as amino does.
+func (spk SigPubKey) MarshalJSON() ([]byte, error) {
+	if spk.Type != multisigPubKeyType {
+		return json.Marshal(struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		}{spk.Type, spk.Value})
+	}
+
+	return json.Marshal(struct {
+		Type  string              `json:"type"`
+		Value multisigPubKeyValue `json:"value"`
+	}{
+		Type: spk.Type,
+		Value: multisigPubKeyValue{
+			Threshold: spk.Threshold,
+			PubKeys:   spk.PubKeys,
+		},
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for SigPubKey.
+func (spk *SigPubKey) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*spk = SigPubKey{Type: raw.Type}
+
+	if len(raw.Value) == 0 {
+		return nil
+	}
+
+	if raw.Type != multisigPubKeyType {
+		return json.Unmarshal(raw.Value, &spk.Value)
+	}
+
+	var value multisigPubKeyValue
+	if err := json.Unmarshal(raw.Value, &value); err != nil {
+		return err
+	}
+
+	spk.Threshold = value.Threshold
+	spk.PubKeys = value.PubKeys
+
+	return nil
+}
+
+// PartialSign signs tx with given chainID, accountNumber and sequenceNumber of a multisig
+// account, with w's private key.
+// The resulting Signature must be combined with the ones of the other signers with
+// CombineSignatures.
+func (w Wallet) PartialSign(tx TransactionPayload, chainID, accountNumber, sequenceNumber string) (Signature, error) {
+	signedTx, err := w.Sign(tx, chainID, accountNumber, sequenceNumber)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	return signedTx.Signatures[0], nil
+}
+
+// CombineSignatures combines signatures produced by PartialSign into the multisignature
+// of multisigPubKey, and returns tx signed with it.
+// Each signature must have been made over tx with the given chainID, accountNumber and
+// sequenceNumber by the public key it claims. At least as many signatures as
+// multisigPubKey threshold must be provided.
+func CombineSignatures(tx TransactionPayload, chainID, accountNumber, sequenceNumber string, multisigPubKey SigPubKey, signatures ...Signature) (SignedTransactionPayload, error) {
+	if multisigPubKey.Type != multisigPubKeyType {
+		return SignedTransactionPayload{}, ErrUnsupportedPubKeyType(multisigPubKey.Type)
+	}
+
+	pubKey, err := multisigPubKey.tmPubKey()
+	if err != nil {
+		return SignedTransactionPayload{}, err
+	}
+
+	mpk := pubKey.(multisig.PubKeyMultisigThreshold)
+	msig := multisig.NewMultisig(len(mpk.PubKeys))
+
+	tx.Signatures = nil
+	msgBytes := signBytes(tx, chainID, accountNumber, sequenceNumber)
+
+	for _, sig := range signatures {
+		signer, err := sig.SigPubKey.tmPubKey()
+		if err != nil {
+			return SignedTransactionPayload{}, err
+		}
+
+		if err := verifySignature(signer, sig.Signature, msgBytes); err != nil {
+			return SignedTransactionPayload{}, ErrInvalidPartialSignature(sig.SigPubKey.Value)
+		}
+
+		rawSig, err := base64.StdEncoding.DecodeString(sig.Signature)
+		if err != nil {
+			return SignedTransactionPayload{}, ErrInvalidSignature
+		}
+
+		if err := msig.AddSignatureFromPubKey(rawSig, signer, mpk.PubKeys); err != nil {
+			return SignedTransactionPayload{}, ErrSignerNotInMultisig(sig.SigPubKey.Value)
+		}
+	}
+
+	if len(msig.Sigs) < int(mpk.K) {
+		return SignedTransactionPayload{}, ErrNotEnoughSignatures(len(msig.Sigs), int(mpk.K))
+	}
+
+	tx.Signatures = []Signature{
+		{
+			SigPubKey: multisigPubKey,
+			Signature: base64.StdEncoding.EncodeToString(msig.Marshal()),
+		},
+	}
+
+	return SignedTransactionPayload(tx), nil
+}
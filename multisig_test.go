@@ -0,0 +1,179 @@
+package sacco
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMultisigWallets(t *testing.T) []*Wallet {
+	var wallets []*Wallet
+	for _, path := range []string{"m/44'/118'/0'/0/0", "m/44'/118'/0'/0/1", "m/44'/118'/0'/0/2"} {
+		w, err := FromMnemonic("did:com:", testMnemonic, path)
+		assert.NoError(t, err)
+
+		wallets = append(wallets, w)
+	}
+
+	return wallets
+}
+
+func testMultisigPubKey(t *testing.T, threshold int, wallets []*Wallet) SigPubKey {
+	var pubKeys []SigPubKey
+	for _, w := range wallets {
+		pk, err := w.SigPubKey()
+		assert.NoError(t, err)
+
+		pubKeys = append(pubKeys, pk)
+	}
+
+	mpk, err := NewMultisigPubKey(threshold, pubKeys...)
+	assert.NoError(t, err)
+
+	return mpk
+}
+
+func TestNewMultisigPubKey(t *testing.T) {
+	wallets := testMultisigWallets(t)
+
+	pk, err := wallets[0].SigPubKey()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		threshold int
+		pubKeys   []SigPubKey
+		assertion assert.ErrorAssertionFunc
+	}{
+		{"1 of 1", 1, []SigPubKey{pk}, assert.NoError},
+		{"zero threshold", 0, []SigPubKey{pk}, assert.Error},
+		{"threshold greater than keys", 2, []SigPubKey{pk}, assert.Error},
+		{"invalid public key", 1, []SigPubKey{{Type: secp256k1PubKeyType, Value: "invalid"}}, assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMultisigPubKey(tt.threshold, tt.pubKeys...)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestSigPubKey_JSON(t *testing.T) {
+	mpk := testMultisigPubKey(t, 2, testMultisigWallets(t))
+
+	got, err := json.Marshal(mpk)
+	assert.NoError(t, err)
+
+	// compare with the amino JSON representation of the same key
+	tmPubKey, err := mpk.tmPubKey()
+	assert.NoError(t, err)
+
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	want, err := cdc.MarshalJSON(tmPubKey)
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, string(want), string(got))
+
+	var decoded SigPubKey
+	assert.NoError(t, json.Unmarshal(got, &decoded))
+	assert.Equal(t, mpk, decoded)
+}
+
+func TestSigPubKey_Address(t *testing.T) {
+	wallets := testMultisigWallets(t)
+
+	pk, err := wallets[0].SigPubKey()
+	assert.NoError(t, err)
+
+	address, err := pk.Address("did:com:")
+	assert.NoError(t, err)
+	assert.Equal(t, wallets[0].Address, address)
+
+	mpk := testMultisigPubKey(t, 2, wallets)
+	multisigAddress, err := mpk.Address("did:com:")
+	assert.NoError(t, err)
+	assert.NotEqual(t, address, multisigAddress)
+
+	// the order of the public keys is part of the multisig key
+	reversed := testMultisigPubKey(t, 2, []*Wallet{wallets[2], wallets[1], wallets[0]})
+	reversedAddress, err := reversed.Address("did:com:")
+	assert.NoError(t, err)
+	assert.NotEqual(t, multisigAddress, reversedAddress)
+}
+
+// invalidPartialSignature asserts that an error is the ErrInvalidPartialSignature of sig.
+func invalidPartialSignature(sig Signature) assert.ErrorAssertionFunc {
+	return func(t assert.TestingT, err error, _ ...interface{}) bool {
+		return assert.Equal(t, ErrInvalidPartialSignature(sig.SigPubKey.Value), err)
+	}
+}
+
+func TestCombineSignatures(t *testing.T) {
+	wallets := testMultisigWallets(t)
+	mpk := testMultisigPubKey(t, 2, wallets)
+
+	multisigAddress, err := mpk.Address("did:com:")
+	assert.NoError(t, err)
+
+	tx := TransactionPayload{
+		Message: []json.RawMessage{
+			json.RawMessage(`{"type":"cosmos-sdk/MsgSend","value":{"from_address":"` + multisigAddress + `","to_address":"did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm9","amount":[{"denom":"ucommercio","amount":"10"}]}}`),
+		},
+		Fee: Fee{Amount: []Coin{}, Gas: "200000"},
+	}
+
+	var sigs []Signature
+	for _, w := range wallets {
+		sig, err := w.PartialSign(tx, "test-chain-jVvnJ6", "12", "3")
+		assert.NoError(t, err)
+
+		sigs = append(sigs, sig)
+	}
+
+	outsider, err := FromMnemonic("did:com:", testMnemonic, "m/44'/118'/0'/0/3")
+	assert.NoError(t, err)
+
+	outsiderSig, err := outsider.PartialSign(tx, "test-chain-jVvnJ6", "12", "3")
+	assert.NoError(t, err)
+
+	// a signature made for another sequence
+	staleSig, err := wallets[1].PartialSign(tx, "test-chain-jVvnJ6", "12", "2")
+	assert.NoError(t, err)
+
+	// a signature claiming the public key of another signer of the multisig
+	swappedSig := sigs[0]
+	swappedSig.SigPubKey = sigs[1].SigPubKey
+
+	tests := []struct {
+		name       string
+		sigs       []Signature
+		assertion  assert.ErrorAssertionFunc
+		wantVerify bool
+	}{
+		{"2 of 3 signatures", []Signature{sigs[0], sigs[2]}, assert.NoError, true},
+		{"signatures out of order", []Signature{sigs[2], sigs[1]}, assert.NoError, true},
+		{"all signatures", sigs, assert.NoError, true},
+		{"not enough signatures", []Signature{sigs[1]}, assert.Error, false},
+		{"same signature twice", []Signature{sigs[1], sigs[1]}, assert.Error, false},
+		{"signer outside of the multisig", []Signature{sigs[0], outsiderSig}, assert.Error, false},
+		{"signature of another sequence", []Signature{sigs[0], staleSig}, invalidPartialSignature(staleSig), false},
+		{"signature of another signer", []Signature{swappedSig, sigs[2]}, invalidPartialSignature(swappedSig), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signedTx, err := CombineSignatures(tx, "test-chain-jVvnJ6", "12", "3", mpk, tt.sigs...)
+			tt.assertion(t, err)
+
+			if !tt.wantVerify {
+				return
+			}
+
+			assert.NoError(t, Verify(signedTx, "test-chain-jVvnJ6", "12", "3", multisigAddress))
+			assert.Error(t, Verify(signedTx, "test-chain-jVvnJ6", "12", "4", multisigAddress))
+			assert.Error(t, Verify(signedTx, "test-chain-jVvnJ6", "12", "3", wallets[0].Address))
+		})
+	}
+}
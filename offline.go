@@ -0,0 +1,141 @@
+package sacco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// UnsignedTx is a TransactionPayload bundled with everything needed to sign it
+// without contacting an LCD, so that it can be moved from an online machine to
+// an air-gapped one.
+type UnsignedTx struct {
+	Tx            TransactionPayload `json:"tx"`
+	ChainID       string             `json:"chain_id"`
+	AccountNumber string             `json:"account_number"`
+	Sequence      string             `json:"sequence"`
+	Signer        string             `json:"signer"`
+}
+
+// Validate returns an error if any of the signing informations of u is missing or
+// malformed.
+func (u UnsignedTx) Validate() error {
+	switch {
+	case u.ChainID == "":
+		return ErrInvalidUnsignedTx("missing chain id")
+	case u.AccountNumber == "":
+		return ErrInvalidUnsignedTx("missing account number")
+	case u.Sequence == "":
+		return ErrInvalidUnsignedTx("missing sequence")
+	case u.Signer == "":
+		return ErrInvalidUnsignedTx("missing signer")
+	}
+
+	if _, err := strconv.ParseUint(u.AccountNumber, 10, 64); err != nil {
+		return ErrInvalidUnsignedTx(fmt.Sprintf("account number %s is not an integer", u.AccountNumber))
+	}
+
+	if _, err := strconv.ParseUint(u.Sequence, 10, 64); err != nil {
+		return ErrInvalidUnsignedTx(fmt.Sprintf("sequence %s is not an integer", u.Sequence))
+	}
+
+	if _, _, err := bech32.DecodeAndConvert(u.Signer); err != nil {
+		return ErrInvalidUnsignedTx(fmt.Sprintf("signer %s is not a bech32 address", u.Signer))
+	}
+
+	return nil
+}
+
+// PrepareUnsignedTx queries the LCD c is connected to for the chain ID, and for the
+// account number and sequence of signer, and bundles them with tx in an UnsignedTx.
+func (c *Client) PrepareUnsignedTx(ctx context.Context, signer string, tx TransactionPayload) (UnsignedTx, error) {
+	nodeInfo, err := c.NodeInfo(ctx)
+	if err != nil {
+		return UnsignedTx{}, fmt.Errorf("could not get LCD node informations: %w", err)
+	}
+
+	accountData, err := c.AccountData(ctx, signer)
+	if err != nil {
+		return UnsignedTx{}, fmt.Errorf("could not get Account informations for address %s: %w", signer, err)
+	}
+
+	return UnsignedTx{
+		Tx:            tx,
+		ChainID:       nodeInfo.Info.Network,
+		AccountNumber: strconv.FormatInt(accountData.Result.Value.AccountNumber, 10),
+		Sequence:      strconv.FormatInt(accountData.Result.Value.Sequence, 10),
+		Signer:        signer,
+	}, nil
+}
+
+// WriteUnsignedTx writes the JSON representation of u to w.
+func WriteUnsignedTx(w io.Writer, u UnsignedTx) error {
+	return json.NewEncoder(w).Encode(u)
+}
+
+// ReadUnsignedTx reads an UnsignedTx previously written by WriteUnsignedTx from r.
+func ReadUnsignedTx(r io.Reader) (UnsignedTx, error) {
+	var u UnsignedTx
+
+	jdec := json.NewDecoder(r)
+	if err := jdec.Decode(&u); err != nil {
+		return UnsignedTx{}, fmt.Errorf("could not read unsigned transaction: %w", err)
+	}
+
+	if err := u.Validate(); err != nil {
+		return UnsignedTx{}, err
+	}
+
+	return u, nil
+}
+
+// WriteSignedTx writes the JSON representation of tx to w.
+func WriteSignedTx(w io.Writer, tx SignedTransactionPayload) error {
+	return json.NewEncoder(w).Encode(tx)
+}
+
+// ReadSignedTx reads a SignedTransactionPayload previously written by WriteSignedTx from r.
+func ReadSignedTx(r io.Reader) (SignedTransactionPayload, error) {
+	var tx SignedTransactionPayload
+
+	jdec := json.NewDecoder(r)
+	if err := jdec.Decode(&tx); err != nil {
+		return SignedTransactionPayload{}, fmt.Errorf("could not read signed transaction: %w", err)
+	}
+
+	if len(tx.Signatures) == 0 {
+		return SignedTransactionPayload{}, ErrMissingSignature
+	}
+
+	return tx, nil
+}
+
+// SignUnsignedTx signs the transaction held by u with the signing informations it
+// carries, without contacting any LCD.
+// u must have been prepared for w's address.
+func (w Wallet) SignUnsignedTx(u UnsignedTx) (SignedTransactionPayload, error) {
+	if err := u.Validate(); err != nil {
+		return SignedTransactionPayload{}, err
+	}
+
+	if u.Signer != w.Address {
+		return SignedTransactionPayload{}, ErrSignerMismatch(u.Signer, w.Address)
+	}
+
+	return w.Sign(u.Tx, u.ChainID, u.AccountNumber, u.Sequence)
+}
+
+// BroadcastSignedTx reads a SignedTransactionPayload previously written by WriteSignedTx
+// from r, and broadcasts it to the LCD c is connected to.
+func (c *Client) BroadcastSignedTx(ctx context.Context, r io.Reader, txMode TxMode) (TxResponse, error) {
+	tx, err := ReadSignedTx(r)
+	if err != nil {
+		return TxResponse{}, err
+	}
+
+	return c.BroadcastTx(ctx, tx, txMode)
+}
@@ -0,0 +1,198 @@
+package sacco
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/node_info",
+		httpmock.NewStringResponder(http.StatusOK, testNodeInfoJSON))
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+w.Address,
+		httpmock.NewStringResponder(http.StatusOK, `{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"`+w.Address+`","account_number":11,"sequence":5}}}`))
+	recorder := &testSignatureRecorder{}
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/txs", recorder.respond)
+
+	client := NewClient(mockHTTPEndpoint, nil, nil)
+	tx := TransactionPayload{
+		Message: []json.RawMessage{
+			json.RawMessage(`{"type":"cosmos-sdk/MsgSend","value":{"from_address":"did:com:1sfjela2snk9rmmcfh773gm50476w0ur5pmwuak","to_address":"did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm9","amount":[{"denom":"ucommercio","amount":"10"}]}}`),
+		},
+		Fee: Fee{Amount: []Coin{}, Gas: "200000"},
+	}
+
+	// online: prepare the unsigned transaction
+	unsigned, err := client.PrepareUnsignedTx(context.Background(), w.Address, tx)
+	assert.NoError(t, err)
+	assert.Equal(t, UnsignedTx{
+		Tx:            tx,
+		ChainID:       "test-chain-jVvnJ6",
+		AccountNumber: "11",
+		Sequence:      "5",
+		Signer:        w.Address,
+	}, unsigned)
+
+	var unsignedFile bytes.Buffer
+	assert.NoError(t, WriteUnsignedTx(&unsignedFile, unsigned))
+
+	// offline: sign it
+	readUnsigned, err := ReadUnsignedTx(&unsignedFile)
+	assert.NoError(t, err)
+	assert.Equal(t, unsigned, readUnsigned)
+
+	signed, err := w.SignUnsignedTx(readUnsigned)
+	assert.NoError(t, err)
+
+	want, err := w.Sign(tx, "test-chain-jVvnJ6", "11", "5")
+	assert.NoError(t, err)
+	assert.Equal(t, want, signed)
+
+	var signedFile bytes.Buffer
+	assert.NoError(t, WriteSignedTx(&signedFile, signed))
+
+	// online: broadcast it
+	txr, err := client.BroadcastSignedTx(context.Background(), &signedFile, ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, testTxHash, txr.TxHash)
+	assert.Equal(t, []string{signed.Signatures[0].Signature}, recorder.signatures)
+}
+
+func TestWallet_SignUnsignedTx(t *testing.T) {
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		unsigned  UnsignedTx
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"complete unsigned transaction",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "11", Sequence: "5", Signer: w.Address},
+			assert.NoError,
+		},
+		{
+			"transaction prepared for another address",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "11", Sequence: "5", Signer: "did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm9"},
+			assert.Error,
+		},
+		{
+			"missing chain id",
+			UnsignedTx{AccountNumber: "11", Sequence: "5", Signer: w.Address},
+			assert.Error,
+		},
+		{
+			"missing sequence",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "11", Signer: w.Address},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := w.SignUnsignedTx(tt.unsigned)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestUnsignedTx_Validate(t *testing.T) {
+	const signer = "did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm9"
+
+	tests := []struct {
+		name      string
+		unsigned  UnsignedTx
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"complete unsigned transaction",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "11", Sequence: "5", Signer: signer},
+			assert.NoError,
+		},
+		{
+			"missing account number",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", Sequence: "5", Signer: signer},
+			assert.Error,
+		},
+		{
+			"missing signer",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "11", Sequence: "5"},
+			assert.Error,
+		},
+		{
+			"account number not an integer",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "eleven", Sequence: "5", Signer: signer},
+			assert.Error,
+		},
+		{
+			"negative sequence",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "11", Sequence: "-5", Signer: signer},
+			assert.Error,
+		},
+		{
+			"signer not a bech32 address",
+			UnsignedTx{ChainID: "test-chain-jVvnJ6", AccountNumber: "11", Sequence: "5", Signer: "did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm8"},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assertion(t, tt.unsigned.Validate())
+		})
+	}
+}
+
+func TestReadSignedTx(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		wantErr   error
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"signed transaction",
+			`{"msg":[],"fee":{"amount":[],"gas":"200000"},"signatures":[{"pub_key":{"type":"tendermint/PubKeySecp256k1","value":"A+D3Gq0N9rCtx6kIaN9G1cu9AXWnDBgdRNAnSPIj/vhc"},"signature":"c2lnbmF0dXJl"}],"memo":""}`,
+			nil,
+			assert.NoError,
+		},
+		{
+			"unsigned transaction",
+			`{"msg":[],"fee":{"amount":[],"gas":"200000"},"signatures":null,"memo":""}`,
+			ErrMissingSignature,
+			assert.Error,
+		},
+		{
+			"malformed file",
+			`not json`,
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadSignedTx(strings.NewReader(tt.file))
+			tt.assertion(t, err)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+
+			// malformed files must not be mistaken for transactions rejected by the chain
+			assert.False(t, errors.Is(err, ErrNoSignatures))
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package sacco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultHealthCheckInterval is the interval between two health checks run by
+// EndpointPool.Watch, when no interval is given.
+const DefaultHealthCheckInterval = 30 * time.Second
+
+// EndpointStatus is the health of an LCD endpoint, as seen by the last health check
+// of an EndpointPool.
+type EndpointStatus struct {
+	// Endpoint is the base URL of the LCD.
+	Endpoint string
+
+	// Checked reports whether the endpoint has been health checked at least once.
+	Checked bool
+
+	// Syncing reports whether the node is still catching up with the chain.
+	Syncing bool
+
+	// Height is the height of the latest block known to the node.
+	Height int64
+
+	// Err is the error the last health check or request failed with, if any.
+	Err error
+
+	// CheckedAt is the time of the last health check.
+	CheckedAt time.Time
+}
+
+// Healthy reports whether the endpoint passed its last health check, and no request
+// failed against it since then.
+func (s EndpointStatus) Healthy() bool {
+	return s.Checked && !s.Syncing && s.Err == nil
+}
+
+// rank returns the preference class of s: healthy endpoints come first, then unchecked
+// ones, then syncing ones and finally failed ones.
+func (s EndpointStatus) rank() int {
+	switch {
+	case s.Err != nil:
+		return 3
+	case s.Syncing:
+		return 2
+	case !s.Checked:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// EndpointPool is a set of LCD endpoints of the same chain, which are health checked
+// through their /node_info, /syncing and /blocks/latest endpoints, or their
+// /cosmos/base/tendermint/v1beta1 counterparts when SignMode is SignModeDirect.
+// A Client with a Pool sends each request to the healthy endpoint with the highest
+// block first, and fails over to the next one on network errors and 5xx responses.
+// Requests other than GET, like broadcasts, fail over only when an endpoint can't be
+// reached, so that they're never processed twice.
+//
+// An EndpointPool is safe for concurrent use by multiple goroutines.
+type EndpointPool struct {
+	// SignMode is the SignMode of the chain the endpoints belong to, which selects the
+	// routes used to health check them.
+	// Clients returned by NewPoolClient use it as their SignMode.
+	// It must be set before the EndpointPool is used.
+	SignMode SignMode
+
+	httpClient *http.Client
+	header     http.Header
+
+	mu       sync.Mutex
+	statuses []EndpointStatus
+}
+
+// NewEndpointPool returns a new EndpointPool instance given a list of LCD endpoints,
+// the *http.Client and the set of default headers used to health check them.
+// If httpClient is nil, a new *http.Client with DefaultTimeout is used.
+// Endpoints are preferred in the given order until the first health check.
+func NewEndpointPool(endpoints []string, httpClient *http.Client, header http.Header) (*EndpointPool, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	c := NewClient("", httpClient, header)
+
+	statuses := make([]EndpointStatus, len(endpoints))
+	for i, endpoint := range endpoints {
+		statuses[i] = EndpointStatus{Endpoint: endpoint}
+	}
+
+	return &EndpointPool{
+		httpClient: c.HTTPClient,
+		header:     c.Header,
+		statuses:   statuses,
+	}, nil
+}
+
+// NewPoolClient returns a new Client instance which sends its requests to the
+// endpoints of pool, with the *http.Client, default headers and SignMode of pool.
+func NewPoolClient(pool *EndpointPool) *Client {
+	c := NewClient("", pool.httpClient, pool.header)
+	c.Pool = pool
+	c.SignMode = pool.SignMode
+
+	return c
+}
+
+// Statuses returns the status of each endpoint of p, ordered by preference.
+func (p *EndpointPool) Statuses() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := append([]EndpointStatus{}, p.statuses...)
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].rank() != statuses[j].rank() {
+			return statuses[i].rank() < statuses[j].rank()
+		}
+
+		return statuses[i].Height > statuses[j].Height
+	})
+
+	return statuses
+}
+
+// Endpoints returns the endpoints of p, ordered by preference: healthy endpoints with
+// the highest block first, failed endpoints last.
+func (p *EndpointPool) Endpoints() []string {
+	statuses := p.Statuses()
+
+	endpoints := make([]string, len(statuses))
+	for i, status := range statuses {
+		endpoints[i] = status.Endpoint
+	}
+
+	return endpoints
+}
+
+// update replaces the status of endpoint with the result of f.
+func (p *EndpointPool) update(endpoint string, f func(EndpointStatus) EndpointStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, status := range p.statuses {
+		if status.Endpoint == endpoint {
+			p.statuses[i] = f(status)
+			return
+		}
+	}
+}
+
+// MarkFailed records that a request to endpoint failed with err, moving endpoint to
+// the bottom of p until its next successful health check.
+// The mark doesn't expire by itself: unless Watch is running, endpoints are only
+// promoted back by calling CheckHealth.
+func (p *EndpointPool) MarkFailed(endpoint string, err error) {
+	p.update(endpoint, func(status EndpointStatus) EndpointStatus {
+		status.Err = err
+		return status
+	})
+}
+
+// CheckHealth health checks every endpoint of p concurrently, and updates their status.
+// An endpoint is healthy if it replies with its node informations, and it isn't syncing.
+func (p *EndpointPool) CheckHealth(ctx context.Context) {
+	p.mu.Lock()
+	endpoints := make([]string, len(p.statuses))
+	for i, status := range p.statuses {
+		endpoints[i] = status.Endpoint
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, endpoint := range endpoints {
+		wg.Add(1)
+		go func(endpoint string) {
+			defer wg.Done()
+
+			status := p.checkEndpoint(ctx, endpoint)
+			p.update(endpoint, func(EndpointStatus) EndpointStatus {
+				return status
+			})
+		}(endpoint)
+	}
+
+	wg.Wait()
+}
+
+// checkEndpoint returns the current status of endpoint.
+func (p *EndpointPool) checkEndpoint(ctx context.Context, endpoint string) EndpointStatus {
+	c := NewClient(endpoint, p.httpClient, p.header)
+	c.SignMode = p.SignMode
+
+	status := EndpointStatus{
+		Endpoint:  endpoint,
+		Checked:   true,
+		CheckedAt: time.Now(),
+	}
+
+	nodeInfo, err := c.NodeInfo(ctx)
+	if err == nil && nodeInfo.Info.Network == "" {
+		err = ErrMissingNetwork
+	}
+	if err != nil {
+		status.Err = err
+		return status
+	}
+
+	if status.Syncing, err = c.Syncing(ctx); err != nil {
+		status.Err = err
+		return status
+	}
+
+	if status.Height, err = c.LatestBlockHeight(ctx); err != nil {
+		status.Err = err
+	}
+
+	return status
+}
+
+// Watch health checks p every interval, until ctx is done.
+// If interval is zero, DefaultHealthCheckInterval is used.
+// Watch blocks, callers usually run it in its own goroutine.
+func (p *EndpointPool) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.CheckHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// isFailoverStatus reports whether a response with statusCode must be retried on
+// another endpoint.
+func isFailoverStatus(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError
+}
+
+// isUnavailableStatus reports whether a response with statusCode means that the
+// endpoint itself is unavailable, rather than the request failed on the node.
+func isUnavailableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// isDialError reports whether err happened while connecting to an endpoint, hence
+// before any byte of the request has been sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// doPool sends an HTTP request to path on each endpoint of c's Pool, in order of
+// preference, until one of them replies with a non-5xx status.
+// Endpoints which can't be reached or reply as unavailable are marked as failed.
+// Since a node may have already processed a request when it fails, requests other
+// than GET are sent to the next endpoint only if the connection to the current one
+// couldn't be established: any other error or 5xx response is returned as is.
+// If every endpoint fails, the last response or error is returned.
+func (c *Client) doPool(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	endpoints := c.Pool.Endpoints()
+	idempotent := method == http.MethodGet
+
+	var lastErr error
+	for i, endpoint := range endpoints {
+		url := fmt.Sprintf("%s%s", endpoint, path)
+
+		resp, err := doRequest(ctx, c.HTTPClient, c.Header, method, url, bodyReader(body))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+
+			c.Pool.MarkFailed(endpoint, err)
+			if !idempotent && !isDialError(err) {
+				return nil, err
+			}
+
+			lastErr = err
+			continue
+		}
+
+		if isUnavailableStatus(resp.StatusCode) {
+			c.Pool.MarkFailed(endpoint, newHTTPError(resp))
+		}
+
+		if !isFailoverStatus(resp.StatusCode) || !idempotent || i == len(endpoints)-1 {
+			return resp, nil
+		}
+
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	return nil, lastErr
+}
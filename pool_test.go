@@ -0,0 +1,256 @@
+package sacco
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEndpointPool(t *testing.T) {
+	_, err := NewEndpointPool(nil, nil, nil)
+	assert.True(t, errors.Is(err, ErrNoEndpoints))
+
+	pool, err := NewEndpointPool([]string{"http://a:1317", "http://b:1317"}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://a:1317", "http://b:1317"}, pool.Endpoints())
+}
+
+// registerHealthyNode registers the health check responders of a node at endpoint.
+func registerHealthyNode(endpoint string, syncing bool, height string) {
+	syncingResp := `{"syncing":false}`
+	if syncing {
+		syncingResp = `{"syncing":true}`
+	}
+
+	httpmock.RegisterResponder("GET", endpoint+"/node_info",
+		httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"testchain"}}`))
+	httpmock.RegisterResponder("GET", endpoint+"/syncing",
+		httpmock.NewStringResponder(http.StatusOK, syncingResp))
+	httpmock.RegisterResponder("GET", endpoint+"/blocks/latest",
+		httpmock.NewStringResponder(http.StatusOK, `{"block_id":{},"block":{"header":{"chain_id":"testchain","height":"`+height+`"}}}`))
+}
+
+func TestEndpointPool_CheckHealth(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerHealthyNode("http://syncing:1317", true, "100")
+	registerHealthyNode("http://lagging:1317", false, "90")
+	registerHealthyNode("http://latest:1317", false, "95")
+	httpmock.RegisterResponder("GET", "http://broken:1317/node_info",
+		httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"internal error"}`))
+
+	pool, err := NewEndpointPool([]string{
+		"http://down:1317",
+		"http://broken:1317",
+		"http://syncing:1317",
+		"http://lagging:1317",
+		"http://latest:1317",
+	}, nil, nil)
+	require.NoError(t, err)
+
+	pool.CheckHealth(context.Background())
+
+	statuses := pool.Statuses()
+	require.Len(t, statuses, 5)
+
+	assert.Equal(t, "http://latest:1317", statuses[0].Endpoint)
+	assert.True(t, statuses[0].Healthy())
+	assert.Equal(t, int64(95), statuses[0].Height)
+
+	assert.Equal(t, "http://lagging:1317", statuses[1].Endpoint)
+	assert.True(t, statuses[1].Healthy())
+
+	assert.Equal(t, "http://syncing:1317", statuses[2].Endpoint)
+	assert.False(t, statuses[2].Healthy())
+	assert.True(t, statuses[2].Syncing)
+
+	assert.Equal(t, "http://down:1317", statuses[3].Endpoint)
+	assert.Error(t, statuses[3].Err)
+
+	assert.Equal(t, "http://broken:1317", statuses[4].Endpoint)
+	assert.True(t, errors.Is(statuses[4].Err, ErrMissingNetwork))
+}
+
+func TestClient_Pool_Failover(t *testing.T) {
+	account := `{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"` + testAccAddress + `","account_number":11,"sequence":3}}}`
+
+	tests := []struct {
+		name          string
+		responders    map[string]httpmock.Responder
+		wantErr       bool
+		wantEndpoints []string
+	}{
+		{
+			"first endpoint replies",
+			map[string]httpmock.Responder{
+				"http://a:1317": httpmock.NewStringResponder(http.StatusOK, account),
+				"http://b:1317": httpmock.NewStringResponder(http.StatusOK, account),
+			},
+			false,
+			[]string{"http://a:1317", "http://b:1317", "http://c:1317"},
+		},
+		{
+			"failover on network error and unavailable endpoint",
+			map[string]httpmock.Responder{
+				"http://b:1317": httpmock.NewStringResponder(http.StatusServiceUnavailable, `service unavailable`),
+				"http://c:1317": httpmock.NewStringResponder(http.StatusOK, account),
+			},
+			false,
+			[]string{"http://c:1317", "http://a:1317", "http://b:1317"},
+		},
+		{
+			"failover on internal error doesn't mark the endpoint as failed",
+			map[string]httpmock.Responder{
+				"http://a:1317": httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"internal error"}`),
+				"http://b:1317": httpmock.NewStringResponder(http.StatusOK, account),
+			},
+			false,
+			[]string{"http://a:1317", "http://b:1317", "http://c:1317"},
+		},
+		{
+			"every endpoint fails",
+			map[string]httpmock.Responder{
+				"http://a:1317": httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"internal error"}`),
+				"http://b:1317": httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"internal error"}`),
+				"http://c:1317": httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"internal error"}`),
+			},
+			true,
+			[]string{"http://a:1317", "http://b:1317", "http://c:1317"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			for endpoint, responder := range tt.responders {
+				httpmock.RegisterResponder("GET", endpoint+"/auth/accounts/"+testAccAddress, responder)
+			}
+
+			pool, err := NewEndpointPool([]string{"http://a:1317", "http://b:1317", "http://c:1317"}, nil, nil)
+			require.NoError(t, err)
+
+			got, err := NewPoolClient(pool).AccountData(context.Background(), testAccAddress)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(11), got.Result.Value.AccountNumber)
+			}
+
+			assert.Equal(t, tt.wantEndpoints, pool.Endpoints())
+		})
+	}
+}
+
+func TestClient_Pool_BroadcastFailover(t *testing.T) {
+	const txhash = "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656"
+
+	dialErr := func(req *http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}
+	readErr := func(req *http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	}
+
+	tests := []struct {
+		name       string
+		firstNode  httpmock.Responder
+		wantBodies int
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"connection refused, the tx is resent to the next endpoint",
+			dialErr,
+			1,
+			assert.NoError,
+		},
+		{
+			"bad gateway, the tx isn't resent",
+			httpmock.NewStringResponder(http.StatusBadGateway, `bad gateway`),
+			0,
+			assert.Error,
+		},
+		{
+			"internal error, the tx isn't resent",
+			httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"timed out waiting for tx to be included in a block"}`),
+			0,
+			assert.Error,
+		},
+		{
+			"connection reset after the tx was sent, the tx isn't resent",
+			readErr,
+			0,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			var bodies []string
+			httpmock.RegisterResponder("POST", "http://a:1317/txs", tt.firstNode)
+			httpmock.RegisterResponder("POST", "http://b:1317/txs",
+				func(req *http.Request) (*http.Response, error) {
+					body, err := ioutil.ReadAll(req.Body)
+					require.NoError(t, err)
+					bodies = append(bodies, string(body))
+
+					return httpmock.NewStringResponse(http.StatusOK, `{"height":"0","txhash":"`+txhash+`"}`), nil
+				})
+
+			pool, err := NewEndpointPool([]string{"http://a:1317", "http://b:1317"}, nil, nil)
+			require.NoError(t, err)
+
+			txr, err := NewPoolClient(pool).BroadcastTx(context.Background(), SignedTransactionPayload{Memo: "sacco"}, ModeSync)
+			tt.assertion(t, err)
+
+			require.Len(t, bodies, tt.wantBodies)
+			if tt.wantBodies > 0 {
+				assert.Equal(t, txhash, txr.TxHash)
+				assert.Contains(t, bodies[0], `"memo":"sacco"`)
+			}
+		})
+	}
+}
+
+func TestEndpointPool_CheckHealth_SignModeDirect(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for endpoint, height := range map[string]string{"http://a:1317": "90", "http://b:1317": "95"} {
+		httpmock.RegisterResponder("GET", endpoint+"/cosmos/base/tendermint/v1beta1/node_info",
+			httpmock.NewStringResponder(http.StatusOK, `{"default_node_info":{"network":"testchain"}}`))
+		httpmock.RegisterResponder("GET", endpoint+"/cosmos/base/tendermint/v1beta1/syncing",
+			httpmock.NewStringResponder(http.StatusOK, `{"syncing":false}`))
+		httpmock.RegisterResponder("GET", endpoint+"/cosmos/base/tendermint/v1beta1/blocks/latest",
+			httpmock.NewStringResponder(http.StatusOK, `{"block_id":{},"block":{"header":{"chain_id":"testchain","height":"`+height+`"}}}`))
+	}
+
+	pool, err := NewEndpointPool([]string{"http://a:1317", "http://b:1317"}, nil, nil)
+	require.NoError(t, err)
+	pool.SignMode = SignModeDirect
+
+	pool.CheckHealth(context.Background())
+
+	statuses := pool.Statuses()
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, "http://b:1317", statuses[0].Endpoint)
+	assert.True(t, statuses[0].Healthy())
+	assert.Equal(t, int64(95), statuses[0].Height)
+
+	assert.Equal(t, "http://a:1317", statuses[1].Endpoint)
+	assert.True(t, statuses[1].Healthy())
+
+	assert.Equal(t, SignModeDirect, NewPoolClient(pool).SignMode)
+}
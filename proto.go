@@ -0,0 +1,94 @@
+package sacco
+
+import "encoding/binary"
+
+// Protobuf wire types used by protoBuffer.
+const (
+	protoVarint = 0
+	protoBytes  = 2
+)
+
+// protoBuffer encodes protobuf messages field by field, following the protobuf
+// binary wire format.
+// Fields holding their default value are omitted, as proto3 mandates, so that
+// the encoding is deterministic.
+type protoBuffer struct {
+	buf []byte
+}
+
+// Bytes returns the encoded message.
+func (b *protoBuffer) Bytes() []byte {
+	return b.buf
+}
+
+// key appends the key of field with the given wire type.
+func (b *protoBuffer) key(field int, wireType int) {
+	b.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+// varint appends v encoded as a base 128 varint.
+func (b *protoBuffer) varint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	b.buf = append(b.buf, tmp[:n]...)
+}
+
+// Uint64 appends v as field.
+func (b *protoBuffer) Uint64(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+
+	b.key(field, protoVarint)
+	b.varint(v)
+}
+
+// BytesField appends v as field.
+func (b *protoBuffer) BytesField(field int, v []byte) {
+	if len(v) == 0 {
+		return
+	}
+
+	b.key(field, protoBytes)
+	b.varint(uint64(len(v)))
+	b.buf = append(b.buf, v...)
+}
+
+// String appends v as field.
+func (b *protoBuffer) String(field int, v string) {
+	b.BytesField(field, []byte(v))
+}
+
+// Message appends the encoded message v as field.
+// Unlike scalar fields, embedded messages are always encoded, even when empty.
+func (b *protoBuffer) Message(field int, v []byte) {
+	b.key(field, protoBytes)
+	b.varint(uint64(len(v)))
+	b.buf = append(b.buf, v...)
+}
+
+// protoAny returns the encoding of a google.protobuf.Any message holding value,
+// whose type is identified by typeURL.
+func protoAny(typeURL string, value []byte) []byte {
+	var b protoBuffer
+	b.String(1, typeURL)
+	b.BytesField(2, value)
+
+	return b.Bytes()
+}
+
+// protoCoin returns the encoding of a cosmos.base.v1beta1.Coin message.
+func protoCoin(coin Coin) []byte {
+	var b protoBuffer
+	b.String(1, coin.Denom)
+	b.String(2, coin.Amount)
+
+	return b.Bytes()
+}
+
+// Coins appends each of coins as a repeated cosmos.base.v1beta1.Coin field.
+func (b *protoBuffer) Coins(field int, coins []Coin) {
+	for _, coin := range coins {
+		b.Message(field, protoCoin(coin))
+	}
+}
@@ -0,0 +1,154 @@
+package sacco
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy describes how many times, and how often, a failed LCD request is retried.
+// Between two attempts, a RetryPolicy waits an exponentially increasing backoff, with
+// some random jitter so that many clients don't retry in lockstep.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, the first one included.
+	// If lower than 2, requests aren't retried.
+	MaxAttempts int
+
+	// InitialBackoff is the time waited before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the time waited between two attempts.
+	// If zero, the backoff isn't capped.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor the backoff grows by after each retry.
+	// If lower than 1, the backoff is constant.
+	Multiplier float64
+
+	// Jitter is the fraction of the backoff randomly added or subtracted to it,
+	// between 0 and 1.
+	Jitter float64
+
+	// Retryable reports whether a request failed with err can be retried.
+	// If nil, IsRetryableQueryError is used for queries, and IsRetryableBroadcastError
+	// for broadcasts.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy is a RetryPolicy suitable for most LCDs: up to 4 attempts,
+// waiting 500ms, 1s and 2s between them, with 20% jitter.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// Backoff returns the time to wait before the given retry, starting from zero.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := float64(p.InitialBackoff)
+	if p.Multiplier > 1 {
+		backoff *= math.Pow(p.Multiplier, float64(retry))
+	}
+
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(backoff)
+}
+
+// retry calls f until it succeeds, it fails with an error which isn't retryable, or
+// policy runs out of attempts, waiting policy backoff between two calls.
+// If policy is nil, f is called once.
+// defaultRetryable classifies errors when policy has no Retryable.
+func retry(ctx context.Context, policy *RetryPolicy, defaultRetryable func(error) bool, f func() error) error {
+	if policy == nil {
+		return f()
+	}
+
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = defaultRetryable
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(policy.Backoff(attempt - 1))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// IsRetryableQueryError reports whether an idempotent LCD query failed with err because
+// of a transient condition: a network error, or the LCD being rate limited or
+// temporarily unavailable.
+func IsRetryableQueryError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || isUnavailableStatus(httpErr.StatusCode)
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsRetryableBroadcastError reports whether a transaction broadcast failed with err
+// for a reason which guarantees that it hasn't been included in the mempool, so that
+// broadcasting it again can't consume its sequence twice: the LCD couldn't be
+// connected to, it rate limited the request, or the node mempool is full.
+func IsRetryableBroadcastError(err error) bool {
+	if errors.Is(err, ErrMempoolIsFull) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isRetryableStatus reports whether a query response with statusCode must be classified
+// as a possibly retryable error.
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// bufferHTTPError reads resp body into an *HTTPError, leaving resp body readable again.
+func bufferHTTPError(resp *http.Response) *HTTPError {
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	httpErr := newHTTPError(resp)
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return httpErr
+}
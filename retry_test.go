@@ -0,0 +1,203 @@
+package sacco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryPolicy retries up to 3 times, without actually waiting.
+var testRetryPolicy = &RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, p.Backoff(0))
+	assert.Equal(t, 200*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 300*time.Millisecond, p.Backoff(2))
+	assert.Equal(t, 300*time.Millisecond, p.Backoff(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := p.Backoff(1)
+		assert.True(t, backoff >= 100*time.Millisecond && backoff <= 300*time.Millisecond, backoff)
+	}
+}
+
+func dialError(t *testing.T) error {
+	// nothing listens on port 1, the connection is refused
+	_, err := net.Dial("tcp", "127.0.0.1:1")
+	require.Error(t, err)
+
+	return err
+}
+
+func TestIsRetryableQueryError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"dial error", dialError(t), true},
+		{"service unavailable", fmt.Errorf("wrapped: %w", &HTTPError{StatusCode: http.StatusServiceUnavailable}), true},
+		{"too many requests", &HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{"internal server error", &HTTPError{StatusCode: http.StatusInternalServerError}, false},
+		{"bad request", &HTTPError{StatusCode: http.StatusBadRequest}, false},
+		{"context canceled", context.Canceled, false},
+		{"other error", errors.New("error"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryableQueryError(tt.err))
+		})
+	}
+}
+
+func TestIsRetryableBroadcastError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"dial error", dialError(t), true},
+		{"mempool is full", &TxError{Codespace: "sdk", Code: 20, RawLog: "mempool is full"}, true},
+		{"too many requests", &HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{"service unavailable", &HTTPError{StatusCode: http.StatusServiceUnavailable}, false},
+		{"insufficient funds", &TxError{Codespace: "sdk", Code: 5, RawLog: "insufficient funds"}, false},
+		{"tx already in mempool", &TxError{Codespace: "sdk", Code: 19, RawLog: "tx already in mempool"}, false},
+		{"timeout", &net.OpError{Op: "read", Err: errors.New("i/o timeout")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryableBroadcastError(tt.err))
+		})
+	}
+}
+
+func TestClient_QueryRetry(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+	account := `{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"` + testAccAddress + `","account_number":11,"sequence":3}}}`
+
+	tests := []struct {
+		name      string
+		policy    *RetryPolicy
+		statuses  []int
+		wantCalls int
+		assertion assert.ErrorAssertionFunc
+	}{
+		{"transient failures", testRetryPolicy, []int{503, 502, 200}, 3, assert.NoError},
+		{"attempts exhausted", testRetryPolicy, []int{503, 503, 503, 200}, 3, assert.Error},
+		{"not retryable failure", testRetryPolicy, []int{500, 200}, 1, assert.Error},
+		{"no retry policy", nil, []int{503, 200}, 1, assert.Error},
+		{
+			"custom classification",
+			&RetryPolicy{MaxAttempts: 3, Retryable: func(error) bool { return true }},
+			[]int{500, 200},
+			2,
+			assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			calls := 0
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+testAccAddress,
+				func(req *http.Request) (*http.Response, error) {
+					status := tt.statuses[calls]
+					calls++
+
+					if status != http.StatusOK {
+						return httpmock.NewStringResponse(status, `{"error":"unavailable"}`), nil
+					}
+
+					return httpmock.NewStringResponse(status, account), nil
+				})
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			c.QueryRetry = tt.policy
+
+			_, err := c.AccountData(context.Background(), testAccAddress)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.wantCalls, calls)
+
+			var httpErr *HTTPError
+			if err != nil {
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, "unavailable", httpErr.Message)
+			}
+		})
+	}
+}
+
+func TestClient_BroadcastRetry(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	mempoolFull := `{"height":"0","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","codespace":"sdk","code":20,"raw_log":"mempool is full"}`
+	accepted := `{"height":"0","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656"}`
+	rejected := `{"height":"0","txhash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","codespace":"sdk","code":5,"raw_log":"insufficient funds"}`
+
+	tests := []struct {
+		name      string
+		responses []string
+		statuses  []int
+		wantCalls int
+		assertion assert.ErrorAssertionFunc
+	}{
+		{"mempool full", []string{mempoolFull, accepted}, []int{200, 200}, 2, assert.NoError},
+		{"rejected transaction", []string{rejected, accepted}, []int{200, 200}, 1, assert.Error},
+		{"internal server error", []string{`{"error":"internal"}`, accepted}, []int{500, 200}, 1, assert.Error},
+		{"too many requests", []string{`{"error":"slow down"}`, accepted}, []int{429, 200}, 2, assert.NoError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			calls := 0
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/txs",
+				func(req *http.Request) (*http.Response, error) {
+					resp := httpmock.NewStringResponse(tt.statuses[calls], tt.responses[calls])
+					calls++
+
+					return resp, nil
+				})
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			c.BroadcastRetry = testRetryPolicy
+
+			_, err := c.BroadcastTx(context.Background(), SignedTransactionPayload{}, ModeSync)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestClient_BroadcastRetry_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := NewClient("http://127.0.0.1:1", nil, nil)
+	c.BroadcastRetry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}
+
+	_, err := c.BroadcastTx(ctx, SignedTransactionPayload{}, ModeSync)
+	assert.Error(t, err)
+}
@@ -0,0 +1,464 @@
+package sacco
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+)
+
+// accountQueryPath is the ABCI query path of the auth module account querier.
+const accountQueryPath = "custom/acc/account"
+
+// RPCClient is a facility used to talk to a Tendermint JSON-RPC service, like
+// "http://localhost:26657".
+// Every request issued by an RPCClient is bound to a context.Context.
+//
+// Transactions are broadcast in their amino binary encoding: messages defined by sacco
+// are encoded whatever the Bech32 prefix of the chain, while any other message they
+// include must be registered in Codec.
+// Addresses of messages registered in Codec only are decoded with the Cosmos SDK global
+// configuration, hence chains not using the "cosmos" Bech32 prefix must set theirs
+// through types.GetConfig() before broadcasting them.
+//
+// An RPCClient is safe for concurrent use by multiple goroutines.
+type RPCClient struct {
+	// Endpoint is the URL of the Tendermint RPC, e.g. "http://localhost:26657".
+	Endpoint string
+
+	// HTTPClient is the *http.Client used to issue requests.
+	HTTPClient *http.Client
+
+	// Header holds the default headers sent along every request.
+	Header http.Header
+
+	// Codec is the amino codec used to encode messages not defined by sacco.
+	Codec *codec.Codec
+}
+
+// NewRPCClient returns a new RPCClient instance given a Tendermint RPC endpoint, an
+// *http.Client and a set of default headers.
+// If httpClient is nil, a new *http.Client with DefaultTimeout is used.
+// The RPCClient Codec is the one returned by NewCodec.
+func NewRPCClient(endpoint string, httpClient *http.Client, header http.Header) *RPCClient {
+	c := NewClient(endpoint, httpClient, header)
+
+	return &RPCClient{
+		Endpoint:   c.Endpoint,
+		HTTPClient: c.HTTPClient,
+		Header:     c.Header,
+		Codec:      NewCodec(),
+	}
+}
+
+// NewCodec returns an amino codec with the Cosmos SDK crypto types, transactions and
+// the messages of the auth, bank, staking, distribution and gov modules registered.
+// Chain-specific messages must be registered on top of it.
+func NewCodec() *codec.Codec {
+	cdc := codec.New()
+
+	sdkTypes.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	auth.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	staking.RegisterCodec(cdc)
+	gov.RegisterCodec(cdc)
+	distribution.RegisterCodec(cdc)
+
+	return cdc
+}
+
+// rpcRequest is a JSON-RPC 2.0 request.
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      string      `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// call invokes method with params on the Tendermint RPC, and decodes its result
+// into result.
+func (c *RPCClient) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      "sacco",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest(ctx, c.HTTPClient, c.Header, http.MethodPost, c.Endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+
+	jdec := json.NewDecoder(resp.Body)
+	if err := jdec.Decode(&rpcResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return newHTTPError(resp)
+		}
+
+		return fmt.Errorf("could not deserialize %s response from rpc: %w", method, err)
+	}
+
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("could not deserialize %s result from rpc: %w", method, err)
+	}
+
+	return nil
+}
+
+// NodeInfo returns useful information of the full node, like the Network
+// (chain) name.
+func (c *RPCClient) NodeInfo(ctx context.Context) (NodeInfo, error) {
+	var nodeInfo NodeInfo
+
+	if err := c.call(ctx, "status", map[string]interface{}{}, &nodeInfo); err != nil {
+		return NodeInfo{}, fmt.Errorf("error during status query: %w", err)
+	}
+
+	return nodeInfo, nil
+}
+
+// abciQueryResult is the result of an abci_query RPC call.
+type abciQueryResult struct {
+	Response struct {
+		Code      uint32 `json:"code"`
+		Log       string `json:"log"`
+		Value     []byte `json:"value"`
+		Codespace string `json:"codespace"`
+	} `json:"response"`
+}
+
+// AccountData retrieves the account data related to the given wallet address, like
+// account number and sequence number, through the auth module ABCI querier.
+// If the account has never been used on chain, an error wrapping ErrAccountNotOnline
+// is returned.
+func (c *RPCClient) AccountData(ctx context.Context, address string) (AccountData, error) {
+	account, err := c.Account(ctx, address)
+	if err != nil {
+		return AccountData{}, err
+	}
+
+	return newAccountData(account), nil
+}
+
+// Account retrieves the whole state of the account related to the given wallet address,
+// like its coins, public key and vesting schedule, through the auth module ABCI querier.
+// If the account has never been used on chain, an error wrapping ErrAccountNotOnline
+// is returned.
+func (c *RPCClient) Account(ctx context.Context, address string) (Account, error) {
+	data, err := json.Marshal(map[string]string{"Address": address})
+	if err != nil {
+		return Account{}, err
+	}
+
+	var res abciQueryResult
+
+	err = c.call(ctx, "abci_query", map[string]interface{}{
+		"path":   accountQueryPath,
+		"data":   hex.EncodeToString(data),
+		"height": "0",
+		"prove":  false,
+	}, &res)
+	if err != nil {
+		return Account{}, fmt.Errorf("error during get account data: %w", err)
+	}
+
+	if res.Response.Code != 0 {
+		queryErr := &TxError{
+			Code:      res.Response.Code,
+			Codespace: res.Response.Codespace,
+			RawLog:    res.Response.Log,
+		}
+
+		if queryErr.Is(ErrUnknownAddress) {
+			return Account{}, fmt.Errorf("%w: %s", ErrAccountNotOnline, address)
+		}
+
+		return Account{}, fmt.Errorf("error during get account data: %w", queryErr)
+	}
+
+	var account Account
+
+	if err := json.Unmarshal(res.Response.Value, &account); err != nil {
+		return Account{}, fmt.Errorf("could not unmarshal node response: %w", err)
+	}
+
+	if account.Address == "" {
+		return Account{}, fmt.Errorf("%w: %s", ErrAccountNotOnline, address)
+	}
+
+	return account, nil
+}
+
+// simulateQueryPath is the ABCI query path of the baseapp transaction simulation.
+const simulateQueryPath = "/app/simulate"
+
+// SimulateTx simulates tx as if it was signed by signer, through the baseapp ABCI
+// simulation query, and returns the gas the chain estimates it would consume.
+// Signatures aren't verified during simulations, hence tx is encoded with an empty one.
+func (c *RPCClient) SimulateTx(ctx context.Context, tx TransactionPayload, signer *Wallet) (uint64, error) {
+	sigPubKey, err := signer.SigPubKey()
+	if err != nil {
+		return 0, err
+	}
+
+	simTx := simulationTx(tx)
+	simTx.Signatures = []Signature{{SigPubKey: sigPubKey}}
+
+	txBytes, err := c.EncodeTx(SignedTransactionPayload(simTx))
+	if err != nil {
+		return 0, err
+	}
+
+	var res abciQueryResult
+
+	err = c.call(ctx, "abci_query", map[string]interface{}{
+		"path":   simulateQueryPath,
+		"data":   hex.EncodeToString(txBytes),
+		"height": "0",
+		"prove":  false,
+	}, &res)
+	if err != nil {
+		return 0, fmt.Errorf("error while processing simulation request: %w", err)
+	}
+
+	if res.Response.Code != 0 {
+		return 0, fmt.Errorf("error while processing simulation request: %w", &TxError{
+			Code:      res.Response.Code,
+			Codespace: res.Response.Codespace,
+			RawLog:    res.Response.Log,
+		})
+	}
+
+	cdc := c.Codec
+	if cdc == nil {
+		cdc = NewCodec()
+	}
+
+	var gasUsed uint64
+	if err := cdc.UnmarshalBinaryLengthPrefixed(res.Response.Value, &gasUsed); err != nil {
+		return 0, fmt.Errorf("could not deserialize gas estimate from rpc: %w", err)
+	}
+
+	return gasUsed, nil
+}
+
+// EncodeTx returns the amino binary encoding of tx, as Tendermint expects it.
+// Messages defined by sacco are encoded whatever the Bech32 prefix of their addresses,
+// any other message is decoded and encoded with c Codec.
+func (c *RPCClient) EncodeTx(tx SignedTransactionPayload) ([]byte, error) {
+	cdc := c.Codec
+	if cdc == nil {
+		cdc = NewCodec()
+	}
+
+	msgs := make([][]byte, len(tx.Message))
+	for i, raw := range tx.Message {
+		msgBytes, err := encodeAminoMsg(cdc, raw)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode transaction: %w", err)
+		}
+
+		msgs[i] = msgBytes
+	}
+
+	stdTx := aminoStdTx{
+		Msgs: msgs,
+		Memo: tx.Memo,
+	}
+
+	feeJSON, err := json.Marshal(tx.Fee)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := aminoCdc.UnmarshalJSON(feeJSON, &stdTx.Fee); err != nil {
+		return nil, fmt.Errorf("could not decode transaction: %w", err)
+	}
+
+	if len(tx.Signatures) > 0 {
+		sigsJSON, err := json.Marshal(tx.Signatures)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := aminoCdc.UnmarshalJSON(sigsJSON, &stdTx.Signatures); err != nil {
+			return nil, fmt.Errorf("could not decode transaction: %w", err)
+		}
+	}
+
+	return aminoCdc.MarshalBinaryLengthPrefixed(stdTx)
+}
+
+// encodeAminoMsg returns the amino binary encoding of the amino JSON message raw,
+// prefix bytes included.
+// Messages defined by sacco are decoded into their representation in aminoMsgTypes,
+// any other message is decoded and encoded with cdc.
+func encodeAminoMsg(cdc *codec.Codec, raw json.RawMessage) ([]byte, error) {
+	var envelope struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, err
+	}
+
+	newMsg, ok := aminoMsgTypes[envelope.Type]
+	if !ok {
+		var msg sdkTypes.Msg
+		if err := cdc.UnmarshalJSON(raw, &msg); err != nil {
+			return nil, err
+		}
+
+		return cdc.MarshalBinaryBare(msg)
+	}
+
+	msg := newMsg()
+	if err := json.Unmarshal(envelope.Value, msg); err != nil {
+		return nil, err
+	}
+
+	return aminoCdc.MarshalBinaryBare(reflect.ValueOf(msg).Elem().Interface())
+}
+
+// broadcastResult is the result of the broadcast_tx_sync and broadcast_tx_async RPC calls.
+type broadcastResult struct {
+	Code      uint32 `json:"code"`
+	Data      string `json:"data"`
+	Log       string `json:"log"`
+	Codespace string `json:"codespace"`
+	Hash      string `json:"hash"`
+}
+
+// deliverResult is the result of a CheckTx or DeliverTx ABCI call.
+type deliverResult struct {
+	Code      uint32 `json:"code"`
+	Data      []byte `json:"data"`
+	Log       string `json:"log"`
+	Info      string `json:"info"`
+	GasWanted string `json:"gas_wanted"`
+	GasUsed   string `json:"gas_used"`
+	Codespace string `json:"codespace"`
+}
+
+// broadcastCommitResult is the result of the broadcast_tx_commit RPC call.
+type broadcastCommitResult struct {
+	CheckTx   deliverResult `json:"check_tx"`
+	DeliverTx deliverResult `json:"deliver_tx"`
+	Hash      string        `json:"hash"`
+	Height    string        `json:"height"`
+}
+
+// BroadcastTx broadcasts a tx to the Tendermint RPC c is connected to, and returns the
+// whole TxResponse the node replied with.
+// ModeSync, ModeAsync and ModeBlock map to broadcast_tx_sync, broadcast_tx_async and
+// broadcast_tx_commit respectively.
+// If the transaction has been rejected by the chain, both the TxResponse and an error
+// are returned.
+func (c *RPCClient) BroadcastTx(ctx context.Context, tx SignedTransactionPayload, txMode TxMode) (TxResponse, error) {
+	txBytes, err := c.EncodeTx(tx)
+	if err != nil {
+		return TxResponse{}, err
+	}
+
+	params := map[string]interface{}{
+		"tx": base64.StdEncoding.EncodeToString(txBytes),
+	}
+
+	var txr TxResponse
+
+	switch txMode {
+	case ModeSync, ModeAsync:
+		var res broadcastResult
+		if err := c.call(ctx, "broadcast_tx_"+txMode.String(), params, &res); err != nil {
+			return TxResponse{}, fmt.Errorf("error while processing tx send request: %w", err)
+		}
+
+		txr = TxResponse{
+			Height:    "0",
+			TxHash:    res.Hash,
+			Code:      res.Code,
+			Data:      res.Data,
+			RawLog:    res.Log,
+			Codespace: res.Codespace,
+		}
+	case ModeBlock:
+		var res broadcastCommitResult
+		if err := c.call(ctx, "broadcast_tx_commit", params, &res); err != nil {
+			return TxResponse{}, fmt.Errorf("error while processing tx send request: %w", err)
+		}
+
+		txr = commitTxResponse(res)
+	default:
+		return TxResponse{}, ErrUnknownTxMode(txMode)
+	}
+
+	return txr, txResponseError(txr)
+}
+
+// commitTxResponse returns the TxResponse describing res, taking the result of CheckTx
+// if the transaction failed it, or the one of DeliverTx otherwise.
+func commitTxResponse(res broadcastCommitResult) TxResponse {
+	result := res.DeliverTx
+	if res.CheckTx.Code != 0 {
+		result = res.CheckTx
+	}
+
+	txr := TxResponse{
+		Height:    res.Height,
+		TxHash:    res.Hash,
+		Code:      result.Code,
+		Data:      hex.EncodeToString(result.Data),
+		RawLog:    result.Log,
+		Info:      result.Info,
+		GasWanted: result.GasWanted,
+		GasUsed:   result.GasUsed,
+		Codespace: result.Codespace,
+	}
+
+	if result.Code == 0 {
+		// logs are JSON-encoded only when the transaction succeeds
+		logs, err := sdkTypes.ParseABCILogs(result.Log)
+		if err == nil {
+			txr.Logs = logs
+		}
+	}
+
+	if _, err := strconv.ParseInt(txr.Height, 10, 64); err != nil {
+		txr.Height = "0"
+	}
+
+	return txr
+}
@@ -0,0 +1,516 @@
+package sacco
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	sdkTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// rpcResponder returns a responder which checks that the JSON-RPC request invokes
+// method, and replies with resp.
+func rpcResponder(t *testing.T, method string, resp string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var rpcReq struct {
+			Method string                     `json:"method"`
+			Params map[string]json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&rpcReq))
+		assert.Equal(t, method, rpcReq.Method)
+
+		return httpmock.NewStringResponse(http.StatusOK, resp), nil
+	}
+}
+
+func testRPCSignedTx(t *testing.T) SignedTransactionPayload {
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := TransactionPayload{
+		Fee:  Fee{Amount: []Coin{{Denom: "uatom", Amount: "5000"}}, Gas: "200000"},
+		Memo: "sacco",
+	}
+	require.NoError(t, tx.AddMsgs(MsgSend{
+		FromAddress: w.Address,
+		ToAddress:   testAccAddress,
+		Amount:      Coins{{Denom: "uatom", Amount: "10"}},
+	}))
+
+	signedTx, err := w.Sign(tx, "cosmoshub-3", "11", "3")
+	require.NoError(t, err)
+
+	return signedTx
+}
+
+func TestRPCClient_NodeInfo(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:26657"
+
+	tests := []struct {
+		name      string
+		jsonResp  string
+		want      NodeInfo
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"successful request",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"node_info":{"protocol_version":{"p2p":"7","block":"10","app":"0"},"id":"cb3b6a5f1d4e3b4a","listen_addr":"tcp://0.0.0.0:26656","network":"cosmoshub-3","version":"0.33.0","channels":"4020212223303800","moniker":"node","other":{"tx_index":"on","rpc_address":"tcp://0.0.0.0:26657"}},"sync_info":{"latest_block_height":"1590"}}}`,
+			func() NodeInfo {
+				var ni NodeInfo
+				ni.Info.Network = "cosmoshub-3"
+				return ni
+			}(),
+			assert.NoError,
+		},
+		{
+			"rpc error",
+			`{"jsonrpc":"2.0","id":"sacco","error":{"code":-32603,"message":"Internal error","data":"node is not ready"}}`,
+			NodeInfo{},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint, rpcResponder(t, "status", tt.jsonResp))
+
+			c := NewRPCClient(mockHTTPEndpoint, nil, nil)
+			got, err := c.NodeInfo(context.Background())
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRPCClient_AccountData(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:26657"
+
+	account := `{"type":"cosmos-sdk/Account","value":{"address":"` + testAccAddress + `","coins":[{"denom":"uatom","amount":"10"}],"public_key":null,"account_number":11,"sequence":3}}`
+	vestingAccount := `{"type":"cosmos-sdk/DelayedVestingAccount","value":{"address":"` + testAccAddress + `","coins":[],"public_key":"","account_number":"11","sequence":"3","original_vesting":[],"delegated_free":[],"delegated_vesting":[],"end_time":"1609459200"}}`
+
+	tests := []struct {
+		name      string
+		jsonResp  string
+		want      AccountData
+		wantErr   error
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"existing account",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"response":{"code":0,"log":"","value":"` + base64.StdEncoding.EncodeToString([]byte(account)) + `","height":"1590"}}}`,
+			AccountData{
+				Result: AccountDataResult{
+					Value: AccountDataValue{
+						Address:       testAccAddress,
+						AccountNumber: 11,
+						Sequence:      3,
+					},
+				},
+			},
+			nil,
+			assert.NoError,
+		},
+		{
+			"vesting account",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"response":{"code":0,"log":"","value":"` + base64.StdEncoding.EncodeToString([]byte(vestingAccount)) + `","height":"1590"}}}`,
+			AccountData{
+				Result: AccountDataResult{
+					Value: AccountDataValue{
+						Address:       testAccAddress,
+						AccountNumber: 11,
+						Sequence:      3,
+					},
+				},
+			},
+			nil,
+			assert.NoError,
+		},
+		{
+			"unknown account",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"response":{"code":9,"log":"account ` + testAccAddress + ` does not exist: unknown address","codespace":"sdk","height":"1590"}}}`,
+			AccountData{},
+			ErrAccountNotOnline,
+			assert.Error,
+		},
+		{
+			"query error",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"response":{"code":1,"log":"internal","codespace":"sdk","height":"1590"}}}`,
+			AccountData{},
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint, func(req *http.Request) (*http.Response, error) {
+				var rpcReq struct {
+					Method string `json:"method"`
+					Params struct {
+						Path string `json:"path"`
+						Data string `json:"data"`
+					} `json:"params"`
+				}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&rpcReq))
+				assert.Equal(t, "abci_query", rpcReq.Method)
+				assert.Equal(t, accountQueryPath, rpcReq.Params.Path)
+
+				data, err := hex.DecodeString(rpcReq.Params.Data)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"Address":"`+testAccAddress+`"}`, string(data))
+
+				return httpmock.NewStringResponse(http.StatusOK, tt.jsonResp), nil
+			})
+
+			c := NewRPCClient(mockHTTPEndpoint, nil, nil)
+			got, err := c.AccountData(context.Background(), testAccAddress)
+
+			tt.assertion(t, err)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRPCClient_EncodeTx(t *testing.T) {
+	signedTx := testRPCSignedTx(t)
+
+	c := NewRPCClient("http://127.0.0.1:26657", nil, nil)
+	txBytes, err := c.EncodeTx(signedTx)
+	require.NoError(t, err)
+
+	decoded, err := auth.DefaultTxDecoder(c.Codec)(txBytes)
+	require.NoError(t, err)
+
+	stdTx, ok := decoded.(auth.StdTx)
+	require.True(t, ok)
+
+	assert.Equal(t, "sacco", stdTx.Memo)
+	assert.Equal(t, uint64(200000), stdTx.Fee.Gas)
+	assert.Len(t, stdTx.Signatures, 1)
+
+	sig, err := base64.StdEncoding.DecodeString(signedTx.Signatures[0].Signature)
+	require.NoError(t, err)
+	assert.Equal(t, sig, stdTx.Signatures[0].Signature)
+
+	require.Len(t, stdTx.Msgs, 1)
+	msgSend, ok := stdTx.Msgs[0].(bank.MsgSend)
+	require.True(t, ok)
+	assert.Equal(t, testAccAddress, msgSend.ToAddress.String())
+	assert.Equal(t, sdkTypes.NewCoins(sdkTypes.NewInt64Coin("uatom", 10)), msgSend.Amount)
+
+	// the signature must be valid over the decoded transaction
+	signBytes := auth.StdSignBytes("cosmoshub-3", 11, 3, stdTx.Fee, stdTx.Msgs, stdTx.Memo)
+	assert.True(t, stdTx.Signatures[0].PubKey.VerifyBytes(signBytes, stdTx.Signatures[0].Signature))
+}
+
+func TestRPCClient_EncodeTx_sdkMessages(t *testing.T) {
+	coin := Coin{Denom: "uatom", Amount: "10"}
+
+	tx := TransactionPayload{
+		Fee:  Fee{Amount: []Coin{{Denom: "uatom", Amount: "5000"}}, Gas: "200000"},
+		Memo: "sacco",
+	}
+	require.NoError(t, tx.AddMsgs(
+		MsgSend{FromAddress: testAccAddress, ToAddress: testAccAddress, Amount: Coins{coin}},
+		MsgMultiSend{
+			Inputs:  []Input{{Address: testAccAddress, Coins: Coins{coin}}},
+			Outputs: []Output{{Address: testAccAddress, Coins: Coins{coin}}},
+		},
+		MsgDelegate{DelegatorAddress: testAccAddress, ValidatorAddress: testValAddress, Amount: coin},
+		MsgUndelegate{DelegatorAddress: testAccAddress, ValidatorAddress: testValAddress, Amount: coin},
+		MsgBeginRedelegate{
+			DelegatorAddress:    testAccAddress,
+			ValidatorSrcAddress: testValAddress,
+			ValidatorDstAddress: testValAddress,
+			Amount:              coin,
+		},
+		MsgWithdrawDelegatorReward{DelegatorAddress: testAccAddress, ValidatorAddress: testValAddress},
+		MsgSubmitProposal{
+			Content:        TextProposal{Title: "title", Description: "description"},
+			InitialDeposit: Coins{coin},
+			Proposer:       testAccAddress,
+		},
+		MsgVote{ProposalID: 1, Voter: testAccAddress, Option: OptionYes},
+	))
+
+	signedTx := SignedTransactionPayload(tx)
+
+	c := NewRPCClient("http://127.0.0.1:26657", nil, nil)
+	got, err := c.EncodeTx(signedTx)
+	require.NoError(t, err)
+
+	// the same transaction, encoded by the Cosmos SDK
+	txJSON, err := json.Marshal(aminoJSON{Type: "cosmos-sdk/StdTx", Value: signedTx})
+	require.NoError(t, err)
+
+	var stdTx auth.StdTx
+	require.NoError(t, c.Codec.UnmarshalJSON(txJSON, &stdTx))
+
+	want, err := auth.DefaultTxEncoder(c.Codec)(stdTx)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got)
+}
+
+func TestRPCClient_EncodeTx_didCom(t *testing.T) {
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	const recipient = "did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm9"
+
+	msgSend := MsgSend{
+		FromAddress: w.Address,
+		ToAddress:   recipient,
+		Amount:      Coins{{Denom: "ucommercio", Amount: "10"}},
+	}
+	shareDoc := MsgShareDocument{
+		Sender:     w.Address,
+		Recipients: []string{recipient},
+		UUID:       "d83422c6-6e79-4a99-9767-fcae46dfa371",
+		Metadata: DocumentMetadata{
+			ContentURI: "https://example.com/document/metadata",
+			SchemaType: "uni-sincro",
+		},
+		EncryptionData: &DocumentEncryptionData{
+			Keys:          []DocumentEncryptionKey{{Recipient: recipient, Value: "6F746865"}},
+			EncryptedData: []string{"content_uri"},
+		},
+	}
+
+	tx := TransactionPayload{
+		Fee:  Fee{Amount: []Coin{{Denom: "ucommercio", Amount: "10000"}}, Gas: "200000"},
+		Memo: "sacco",
+	}
+	require.NoError(t, tx.AddMsgs(msgSend, shareDoc))
+
+	signedTx, err := w.Sign(tx, "commercio-testnet", "11", "3")
+	require.NoError(t, err)
+
+	// the Cosmos SDK global configuration is left with the "cosmos" Bech32 prefix
+	c := NewRPCClient("http://127.0.0.1:26657", nil, nil)
+	txBytes, err := c.EncodeTx(signedTx)
+	require.NoError(t, err)
+
+	var stdTx aminoStdTx
+	require.NoError(t, aminoCdc.UnmarshalBinaryLengthPrefixed(txBytes, &stdTx))
+	require.Len(t, stdTx.Msgs, 2)
+	assert.Equal(t, "sacco", stdTx.Memo)
+	assert.Equal(t, uint64(200000), stdTx.Fee.Gas)
+	require.Len(t, stdTx.Signatures, 1)
+
+	var gotSend aminoMsgSend
+	require.NoError(t, aminoCdc.UnmarshalBinaryBare(stdTx.Msgs[0], &gotSend))
+	assert.Equal(t, w.Address, didComAddress(t, gotSend.FromAddress))
+	assert.Equal(t, recipient, didComAddress(t, gotSend.ToAddress))
+
+	var gotShareDoc aminoMsgShareDocument
+	require.NoError(t, aminoCdc.UnmarshalBinaryBare(stdTx.Msgs[1], &gotShareDoc))
+	assert.Equal(t, w.Address, didComAddress(t, gotShareDoc.Sender))
+	require.Len(t, gotShareDoc.Recipients, 1)
+	assert.Equal(t, recipient, didComAddress(t, gotShareDoc.Recipients[0]))
+	assert.Equal(t, shareDoc.UUID, gotShareDoc.UUID)
+	assert.Equal(t, shareDoc.Metadata.SchemaType, gotShareDoc.Metadata.SchemaType)
+	require.NotNil(t, gotShareDoc.EncryptionData)
+	require.Len(t, gotShareDoc.EncryptionData.Keys, 1)
+	assert.Equal(t, recipient, didComAddress(t, gotShareDoc.EncryptionData.Keys[0].Recipient))
+
+	// the Cosmos SDK decodes the same bytes for the messages it knows about
+	var sdkSend sdkTypes.Msg
+	require.NoError(t, c.Codec.UnmarshalBinaryBare(stdTx.Msgs[0], &sdkSend))
+	bankSend, ok := sdkSend.(bank.MsgSend)
+	require.True(t, ok)
+	assert.Equal(t, []byte(gotSend.ToAddress), []byte(bankSend.ToAddress))
+}
+
+// didComAddress returns the "did:com:" Bech32 encoding of addr.
+func didComAddress(t *testing.T, addr aminoAddress) string {
+	s, err := bech32.ConvertAndEncode("did:com:", addr)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestRPCClient_BroadcastTx(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:26657"
+
+	tests := []struct {
+		name       string
+		txMode     TxMode
+		wantMethod string
+		jsonResp   string
+		want       TxResponse
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"sync broadcast",
+			ModeSync,
+			"broadcast_tx_sync",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"code":0,"data":"","log":"[]","codespace":"","hash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656"}}`,
+			TxResponse{
+				Height: "0",
+				TxHash: "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				RawLog: "[]",
+			},
+			assert.NoError,
+		},
+		{
+			"async broadcast",
+			ModeAsync,
+			"broadcast_tx_async",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"code":0,"data":"","log":"","codespace":"","hash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656"}}`,
+			TxResponse{
+				Height: "0",
+				TxHash: "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+			},
+			assert.NoError,
+		},
+		{
+			"sync broadcast rejected by CheckTx",
+			ModeSync,
+			"broadcast_tx_sync",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"code":4,"data":"","log":"signature verification failed","codespace":"sdk","hash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656"}}`,
+			TxResponse{
+				Height:    "0",
+				TxHash:    "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				Code:      4,
+				RawLog:    "signature verification failed",
+				Codespace: "sdk",
+			},
+			assert.Error,
+		},
+		{
+			"commit broadcast included in a block",
+			ModeBlock,
+			"broadcast_tx_commit",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"check_tx":{"code":0,"gas_wanted":"200000","gas_used":"31231"},"deliver_tx":{"code":0,"log":"[{\"msg_index\":0,\"log\":\"\",\"events\":[{\"type\":\"message\",\"attributes\":[{\"key\":\"action\",\"value\":\"send\"}]}]}]","gas_wanted":"200000","gas_used":"52541"},"hash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","height":"1590"}}`,
+			TxResponse{
+				Height: "1590",
+				TxHash: "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				RawLog: `[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"send"}]}]}]`,
+				Logs: sdkTypes.ABCIMessageLogs{
+					{
+						MsgIndex: 0,
+						Log:      "",
+						Events: sdkTypes.StringEvents{
+							{
+								Type: "message",
+								Attributes: []sdkTypes.Attribute{
+									{Key: "action", Value: "send"},
+								},
+							},
+						},
+					},
+				},
+				GasWanted: "200000",
+				GasUsed:   "52541",
+			},
+			assert.NoError,
+		},
+		{
+			"commit broadcast rejected by DeliverTx",
+			ModeBlock,
+			"broadcast_tx_commit",
+			`{"jsonrpc":"2.0","id":"sacco","result":{"check_tx":{"code":0,"gas_wanted":"200000","gas_used":"31231"},"deliver_tx":{"code":5,"log":"insufficient funds","codespace":"sdk","gas_wanted":"200000","gas_used":"41231"},"hash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656","height":"1590"}}`,
+			TxResponse{
+				Height:    "1590",
+				TxHash:    "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656",
+				Code:      5,
+				RawLog:    "insufficient funds",
+				Codespace: "sdk",
+				GasWanted: "200000",
+				GasUsed:   "41231",
+			},
+			assert.Error,
+		},
+		{
+			"rpc error",
+			ModeSync,
+			"broadcast_tx_sync",
+			`{"jsonrpc":"2.0","id":"sacco","error":{"code":-32603,"message":"Internal error","data":"tx already exists in cache"}}`,
+			TxResponse{},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint, rpcResponder(t, tt.wantMethod, tt.jsonResp))
+
+			c := NewRPCClient(mockHTTPEndpoint, nil, nil)
+			got, err := c.BroadcastTx(context.Background(), testRPCSignedTx(t), tt.txMode)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWallet_SignAndBroadcastWithResponse_RPCClient(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:26657"
+
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	account := `{"type":"cosmos-sdk/Account","value":{"address":"` + w.Address + `","coins":[],"public_key":null,"account_number":11,"sequence":3}}`
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint, func(req *http.Request) (*http.Response, error) {
+		var rpcReq struct {
+			Method string `json:"method"`
+			Params struct {
+				Tx string `json:"tx"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&rpcReq))
+
+		switch rpcReq.Method {
+		case "status":
+			return httpmock.NewStringResponse(http.StatusOK, `{"jsonrpc":"2.0","id":"sacco","result":{"node_info":{"network":"cosmoshub-3"}}}`), nil
+		case "abci_query":
+			return httpmock.NewStringResponse(http.StatusOK, `{"jsonrpc":"2.0","id":"sacco","result":{"response":{"code":0,"value":"`+base64.StdEncoding.EncodeToString([]byte(account))+`"}}}`), nil
+		case "broadcast_tx_sync":
+			txBytes, err := base64.StdEncoding.DecodeString(rpcReq.Params.Tx)
+			require.NoError(t, err)
+
+			decoded, err := auth.DefaultTxDecoder(NewCodec())(txBytes)
+			require.NoError(t, err)
+
+			stdTx := decoded.(auth.StdTx)
+			signBytes := auth.StdSignBytes("cosmoshub-3", 11, 3, stdTx.Fee, stdTx.Msgs, stdTx.Memo)
+			assert.True(t, stdTx.Signatures[0].PubKey.VerifyBytes(signBytes, stdTx.Signatures[0].Signature))
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"jsonrpc":"2.0","id":"sacco","result":{"code":0,"hash":"D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656"}}`), nil
+		}
+
+		t.Fatalf("unexpected method %s", rpcReq.Method)
+		return nil, nil
+	})
+
+	tx := TransactionPayload(testRPCSignedTx(t))
+	tx.Signatures = nil
+	got, err := w.SignAndBroadcastWithResponse(context.Background(), NewRPCClient(mockHTTPEndpoint, nil, nil), tx, ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656", got.TxHash)
+}
@@ -0,0 +1,274 @@
+package sacco
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// This file contains the amino binary representation of the messages defined by sacco.
+// Each type mirrors, field by field, the one registered with the same name by the chain,
+// but decodes addresses from their amino JSON representation whatever their Bech32
+// prefix, so that no Cosmos SDK global configuration is involved.
+
+// aminoAddress is an address, encoded as raw bytes in amino binary and decoded from a
+// Bech32 string with any human-readable part.
+type aminoAddress []byte
+
+// UnmarshalJSON implements the json.Unmarshaler interface for aminoAddress.
+func (a *aminoAddress) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if s == "" {
+		*a = nil
+		return nil
+	}
+
+	_, bz, err := bech32.DecodeAndConvert(s)
+	if err != nil {
+		return ErrMalformedAddress(s)
+	}
+
+	*a = bz
+
+	return nil
+}
+
+// aminoStdTx has the same amino binary representation of auth.StdTx, but holds its
+// messages already encoded, prefix bytes included.
+type aminoStdTx struct {
+	Msgs       [][]byte
+	Fee        auth.StdFee
+	Signatures []auth.StdSignature
+	Memo       string
+}
+
+type aminoMsgSend struct {
+	FromAddress aminoAddress   `json:"from_address"`
+	ToAddress   aminoAddress   `json:"to_address"`
+	Amount      sdkTypes.Coins `json:"amount"`
+}
+
+type aminoInputOutput struct {
+	Address aminoAddress   `json:"address"`
+	Coins   sdkTypes.Coins `json:"coins"`
+}
+
+type aminoMsgMultiSend struct {
+	Inputs  []aminoInputOutput `json:"inputs"`
+	Outputs []aminoInputOutput `json:"outputs"`
+}
+
+type aminoMsgDelegate struct {
+	DelegatorAddress aminoAddress  `json:"delegator_address"`
+	ValidatorAddress aminoAddress  `json:"validator_address"`
+	Amount           sdkTypes.Coin `json:"amount"`
+}
+
+type aminoMsgUndelegate aminoMsgDelegate
+
+type aminoMsgBeginRedelegate struct {
+	DelegatorAddress    aminoAddress  `json:"delegator_address"`
+	ValidatorSrcAddress aminoAddress  `json:"validator_src_address"`
+	ValidatorDstAddress aminoAddress  `json:"validator_dst_address"`
+	Amount              sdkTypes.Coin `json:"amount"`
+}
+
+type aminoMsgWithdrawDelegatorReward struct {
+	DelegatorAddress aminoAddress `json:"delegator_address"`
+	ValidatorAddress aminoAddress `json:"validator_address"`
+}
+
+// aminoProposalContent is the amino interface governance proposal contents are
+// registered as.
+type aminoProposalContent interface {
+	proposalContent()
+}
+
+type aminoTextProposal struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func (aminoTextProposal) proposalContent() {}
+
+type aminoMsgSubmitProposal struct {
+	Content        aminoProposalContent
+	InitialDeposit sdkTypes.Coins
+	Proposer       aminoAddress
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for aminoMsgSubmitProposal.
+// Only TextProposal contents can be decoded.
+func (msg *aminoMsgSubmitProposal) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Content        aminoJSON      `json:"content"`
+		InitialDeposit sdkTypes.Coins `json:"initial_deposit"`
+		Proposer       aminoAddress   `json:"proposer"`
+	}
+
+	var content aminoTextProposal
+	raw.Content.Value = &content
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Content.Type != (TextProposal{}).AminoType() {
+		return ErrUnsupportedProposalContent(raw.Content.Type)
+	}
+
+	*msg = aminoMsgSubmitProposal{
+		Content:        content,
+		InitialDeposit: raw.InitialDeposit,
+		Proposer:       raw.Proposer,
+	}
+
+	return nil
+}
+
+type aminoMsgVote struct {
+	ProposalID uint64         `json:"proposal_id,string"`
+	Voter      aminoAddress   `json:"voter"`
+	Option     gov.VoteOption `json:"option"`
+}
+
+type aminoDocumentMetadata struct {
+	ContentURI string                  `json:"content_uri"`
+	SchemaType string                  `json:"schema_type"`
+	Schema     *DocumentMetadataSchema `json:"schema"`
+}
+
+type aminoDocumentEncryptionKey struct {
+	Recipient aminoAddress `json:"recipient"`
+	Value     string       `json:"value"`
+}
+
+type aminoDocumentEncryptionData struct {
+	Keys          []aminoDocumentEncryptionKey `json:"keys"`
+	EncryptedData []string                     `json:"encrypted_data"`
+}
+
+type aminoMsgShareDocument struct {
+	Sender         aminoAddress                 `json:"sender"`
+	Recipients     []aminoAddress               `json:"recipients"`
+	UUID           string                       `json:"uuid"`
+	Metadata       aminoDocumentMetadata        `json:"metadata"`
+	ContentURI     string                       `json:"content_uri"`
+	Checksum       *DocumentChecksum            `json:"checksum"`
+	EncryptionData *aminoDocumentEncryptionData `json:"encryption_data"`
+	DoSign         *DocumentDoSign              `json:"do_sign"`
+}
+
+type aminoMsgSendDocumentReceipt struct {
+	UUID         string       `json:"uuid"`
+	Sender       aminoAddress `json:"sender"`
+	Recipient    aminoAddress `json:"recipient"`
+	TxHash       string       `json:"tx_hash"`
+	DocumentUUID string       `json:"document_uuid"`
+	Proof        string       `json:"proof"`
+}
+
+type aminoDidPubKey struct {
+	ID           string       `json:"id"`
+	Type         string       `json:"type"`
+	Controller   aminoAddress `json:"controller"`
+	PublicKeyPem string       `json:"publicKeyPem"`
+}
+
+type aminoDidProof struct {
+	Type               string    `json:"type"`
+	Created            time.Time `json:"created"`
+	ProofPurpose       string    `json:"proofPurpose"`
+	Controller         string    `json:"controller"`
+	VerificationMethod string    `json:"verificationMethod"`
+	SignatureValue     string    `json:"signatureValue"`
+}
+
+type aminoMsgSetIdentity struct {
+	Context string           `json:"@context"`
+	ID      aminoAddress     `json:"id"`
+	PubKeys []aminoDidPubKey `json:"publicKey"`
+	Proof   aminoDidProof    `json:"proof"`
+	Service []DidService     `json:"service"`
+}
+
+type aminoMsgRequestDidPowerUp struct {
+	Claimant aminoAddress   `json:"claimant"`
+	Amount   sdkTypes.Coins `json:"amount"`
+	Proof    string         `json:"proof"`
+	ID       string         `json:"id"`
+	ProofKey string         `json:"proof_key"`
+}
+
+type aminoMsgInviteUser struct {
+	Recipient aminoAddress `json:"receiver"`
+	Sender    aminoAddress `json:"sender"`
+}
+
+type aminoMsgBuyMembership struct {
+	MembershipType string       `json:"membership_type"`
+	Buyer          aminoAddress `json:"buyer"`
+}
+
+type aminoMsgOpenCdp struct {
+	Depositor       aminoAddress   `json:"depositor"`
+	DepositedAmount sdkTypes.Coins `json:"deposit_amount"`
+}
+
+type aminoMsgCloseCdp struct {
+	Signer    aminoAddress `json:"signer"`
+	Timestamp int64        `json:"cdp_timestamp,string"`
+}
+
+// aminoMsgTypes maps the amino name of each message defined by sacco to a function
+// returning a pointer to a new instance of its amino binary representation.
+var aminoMsgTypes = map[string]func() interface{}{
+	MsgSend{}.AminoType():                    func() interface{} { return new(aminoMsgSend) },
+	MsgMultiSend{}.AminoType():               func() interface{} { return new(aminoMsgMultiSend) },
+	MsgDelegate{}.AminoType():                func() interface{} { return new(aminoMsgDelegate) },
+	MsgUndelegate{}.AminoType():              func() interface{} { return new(aminoMsgUndelegate) },
+	MsgBeginRedelegate{}.AminoType():         func() interface{} { return new(aminoMsgBeginRedelegate) },
+	MsgWithdrawDelegatorReward{}.AminoType(): func() interface{} { return new(aminoMsgWithdrawDelegatorReward) },
+	MsgSubmitProposal{}.AminoType():          func() interface{} { return new(aminoMsgSubmitProposal) },
+	MsgVote{}.AminoType():                    func() interface{} { return new(aminoMsgVote) },
+	MsgShareDocument{}.AminoType():           func() interface{} { return new(aminoMsgShareDocument) },
+	MsgSendDocumentReceipt{}.AminoType():     func() interface{} { return new(aminoMsgSendDocumentReceipt) },
+	MsgSetIdentity{}.AminoType():             func() interface{} { return new(aminoMsgSetIdentity) },
+	MsgRequestDidPowerUp{}.AminoType():       func() interface{} { return new(aminoMsgRequestDidPowerUp) },
+	MsgInviteUser{}.AminoType():              func() interface{} { return new(aminoMsgInviteUser) },
+	MsgBuyMembership{}.AminoType():           func() interface{} { return new(aminoMsgBuyMembership) },
+	MsgOpenCdp{}.AminoType():                 func() interface{} { return new(aminoMsgOpenCdp) },
+	MsgCloseCdp{}.AminoType():                func() interface{} { return new(aminoMsgCloseCdp) },
+}
+
+// aminoCdc is the amino codec the messages defined by sacco, and the transactions
+// including them, are encoded with.
+var aminoCdc = newAminoCodec()
+
+// newAminoCodec returns an amino codec with aminoStdTx and the amino binary representation
+// of each message defined by sacco registered.
+func newAminoCodec() *codec.Codec {
+	cdc := codec.New()
+
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterConcrete(aminoStdTx{}, "cosmos-sdk/StdTx", nil)
+
+	cdc.RegisterInterface((*aminoProposalContent)(nil), nil)
+	cdc.RegisterConcrete(aminoTextProposal{}, TextProposal{}.AminoType(), nil)
+
+	for name, newMsg := range aminoMsgTypes {
+		cdc.RegisterConcrete(reflect.ValueOf(newMsg()).Elem().Interface(), name, nil)
+	}
+
+	return cdc
+}
@@ -0,0 +1,186 @@
+package sacco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+)
+
+// SequenceManager is a facility used to broadcast transactions concurrently from the
+// same Wallet.
+// It caches the chain ID and the account number of each address, and hands out
+// monotonically increasing sequence numbers per address, resyncing them from the
+// LCD whenever the node rejects a transaction because of a sequence mismatch, or
+// whenever the fate of a broadcast transaction is unknown.
+//
+// A SequenceManager is bound to a single chain, and is safe for concurrent use by
+// multiple goroutines.
+type SequenceManager struct {
+	chainIDMu sync.Mutex
+	chainID   string
+
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+// accountSequence holds the signing informations of a single address.
+type accountSequence struct {
+	mu            sync.Mutex
+	synced        bool
+	accountNumber int64
+	sequence      int64
+}
+
+// NewSequenceManager returns a new, empty SequenceManager instance.
+func NewSequenceManager() *SequenceManager {
+	return &SequenceManager{
+		accounts: map[string]*accountSequence{},
+	}
+}
+
+// Reset forgets the account number and sequence of address, forcing
+// sm to resync them from the LCD on the next transaction.
+func (sm *SequenceManager) Reset(address string) {
+	acc := sm.account(address)
+
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	acc.synced = false
+}
+
+// SignAndBroadcast signs tx with the next sequence number of w, and broadcasts it to the node
+// client is connected to.
+// Transactions of the same address are signed and broadcast one at a time, so that they
+// reach the node in sequence order; transactions of different addresses proceed in parallel.
+// If the node rejects tx because of a sequence mismatch, sm resyncs w's sequence from the node
+// and tries again once.
+func (sm *SequenceManager) SignAndBroadcast(ctx context.Context, client Backend, w *Wallet, tx TransactionPayload, txMode TxMode) (TxResponse, error) {
+	chainID, err := sm.getChainID(ctx, client)
+	if err != nil {
+		return TxResponse{}, fmt.Errorf("could not get LCD node informations: %w", err)
+	}
+
+	acc := sm.account(w.Address)
+
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if !acc.synced {
+			if err := acc.sync(ctx, client, w.Address); err != nil {
+				return TxResponse{}, fmt.Errorf("could not get Account informations for address %s: %w", w.Address, err)
+			}
+		}
+
+		txr, err := w.signAndBroadcast(ctx, client, tx, chainID, acc.accountNumber, acc.sequence, txMode)
+
+		var txErr *TxError
+
+		switch {
+		case err == nil:
+			acc.sequence++
+			return txr, nil
+		case errors.Is(err, ErrInvalidSequence):
+			acc.synced = false
+			if attempt == 0 {
+				continue
+			}
+		case errors.As(err, &txErr):
+			// transactions rejected by DeliverTx have been included in a block,
+			// and have consumed their sequence anyway
+			if txr.Height != "" && txr.Height != "0" {
+				acc.sequence++
+			}
+		case isBroadcastOutcomeUnknown(err):
+			// we don't know whether the sequence has been consumed or not,
+			// resync it before the next transaction
+			acc.synced = false
+		}
+
+		return txr, err
+	}
+}
+
+// isBroadcastOutcomeUnknown reports whether err happened after a transaction has been
+// sent to the node, without a definitive answer about its fate, like an unexpected
+// HTTP status or a connection lost while waiting for the reply.
+// Errors which happen before the transaction is sent, like signing errors or dial
+// errors, leave its sequence unused.
+func isBroadcastOutcomeUnknown(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return true
+	}
+
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return true
+	}
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+
+	var opErr *net.OpError
+	return !errors.As(err, &opErr) || opErr.Op != "dial"
+}
+
+// account returns the accountSequence associated to address, creating
+// it if needed.
+func (sm *SequenceManager) account(address string) *accountSequence {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	acc, ok := sm.accounts[address]
+	if !ok {
+		acc = &accountSequence{}
+		sm.accounts[address] = acc
+	}
+
+	return acc
+}
+
+// getChainID returns the chain ID client is connected to, querying
+// the node only the first time.
+// The query holds chainIDMu rather than mu, so that accounts can be looked up
+// meanwhile.
+func (sm *SequenceManager) getChainID(ctx context.Context, client Backend) (string, error) {
+	sm.chainIDMu.Lock()
+	defer sm.chainIDMu.Unlock()
+
+	if sm.chainID != "" {
+		return sm.chainID, nil
+	}
+
+	nodeInfo, err := client.NodeInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sm.chainID = nodeInfo.Info.Network
+
+	return sm.chainID, nil
+}
+
+// sync updates acc with the account number and sequence the node knows about.
+func (acc *accountSequence) sync(ctx context.Context, client Backend, address string) error {
+	accountData, err := client.AccountData(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	acc.accountNumber = accountData.Result.Value.AccountNumber
+	acc.sequence = accountData.Result.Value.Sequence
+	acc.synced = true
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package sacco
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNodeInfoJSON = `{"node_info":{"network":"test-chain-jVvnJ6"}}`
+	testMnemonic     = "innocent pony teach letter mask bulk stuff pool more work cute prepare forest simple sunset sphere aisle luggage task drama fire clutch trial search"
+)
+
+// testSignatureRecorder is an httpmock.Responder which records the signature of
+// each broadcast transaction.
+type testSignatureRecorder struct {
+	mu         sync.Mutex
+	signatures []string
+	responses  []string
+}
+
+func (tsr *testSignatureRecorder) respond(req *http.Request) (*http.Response, error) {
+	tsr.mu.Lock()
+	defer tsr.mu.Unlock()
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var txBody struct {
+		Tx SignedTransactionPayload `json:"tx"`
+	}
+	if err := json.Unmarshal(body, &txBody); err != nil {
+		return nil, err
+	}
+
+	tsr.signatures = append(tsr.signatures, txBody.Tx.Signatures[0].Signature)
+
+	resp := `{"height":"0","txhash":"` + testTxHash + `"}`
+	if len(tsr.responses) > 0 {
+		resp, tsr.responses = tsr.responses[0], tsr.responses[1:]
+	}
+
+	return httpmock.NewStringResponse(http.StatusOK, resp), nil
+}
+
+func TestSequenceManager_SignAndBroadcast(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		concurrentTxs     int
+		responses         []string
+		wantAccountsCalls int
+		wantSignatures    int
+		assertion         assert.ErrorAssertionFunc
+	}{
+		{
+			"concurrent transactions get different sequences",
+			10,
+			nil,
+			1,
+			10,
+			assert.NoError,
+		},
+		{
+			"sequence mismatch triggers a resync",
+			1,
+			[]string{
+				`{"height":"0","txhash":"` + testTxHash + `","codespace":"sdk","code":4,"raw_log":"signature verification failed; verify correct account sequence and chain-id"}`,
+			},
+			2,
+			2,
+			assert.NoError,
+		},
+		{
+			"rejected transaction isn't retried",
+			1,
+			[]string{
+				`{"height":"0","txhash":"` + testTxHash + `","codespace":"sdk","code":5,"raw_log":"insufficient funds"}`,
+			},
+			1,
+			1,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/node_info",
+				httpmock.NewStringResponder(http.StatusOK, testNodeInfoJSON))
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+w.Address,
+				httpmock.NewStringResponder(http.StatusOK, `{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"`+w.Address+`","account_number":11,"sequence":5}}}`))
+
+			recorder := &testSignatureRecorder{responses: tt.responses}
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/txs", recorder.respond)
+
+			client := NewClient(mockHTTPEndpoint, nil, nil)
+			sm := NewSequenceManager()
+
+			var wg sync.WaitGroup
+			errs := make([]error, tt.concurrentTxs)
+			for i := 0; i < tt.concurrentTxs; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					_, errs[i] = sm.SignAndBroadcast(context.Background(), client, w, TransactionPayload{
+						Fee: Fee{Amount: []Coin{}, Gas: "200000"},
+					}, ModeSync)
+				}(i)
+			}
+			wg.Wait()
+
+			for _, err := range errs {
+				tt.assertion(t, err)
+			}
+
+			calls := httpmock.GetCallCountInfo()
+			assert.Equal(t, tt.wantAccountsCalls, calls["GET "+mockHTTPEndpoint+"/auth/accounts/"+w.Address])
+			assert.Equal(t, 1, calls["GET "+mockHTTPEndpoint+"/node_info"])
+
+			// signatures are deterministic, so different sequences yield different signatures
+			assert.Len(t, recorder.signatures, tt.wantSignatures)
+			if tt.concurrentTxs > 1 {
+				unique := map[string]bool{}
+				for _, s := range recorder.signatures {
+					unique[s] = true
+				}
+				assert.Len(t, unique, tt.concurrentTxs)
+			}
+		})
+	}
+}
+
+func TestSequenceManager_SignAndBroadcast_SignModeDirect(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:1317"
+
+	w, err := FromMnemonic("cosmos", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tx := testDirectTx(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/base/tendermint/v1beta1/node_info",
+		httpmock.NewStringResponder(http.StatusOK, `{"default_node_info":{"network":"cosmoshub-4"}}`))
+	// the account sequence is 5 on the first query, 6 on the second one
+	sequence := 4
+	httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/cosmos/auth/v1beta1/accounts/"+w.Address,
+		func(req *http.Request) (*http.Response, error) {
+			sequence++
+			return httpmock.NewStringResponse(http.StatusOK, `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"`+w.Address+`","account_number":"11","sequence":"`+strconv.Itoa(sequence)+`"}}`), nil
+		})
+
+	var txBytes []string
+	httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/cosmos/tx/v1beta1/txs",
+		func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				TxBytes string `json:"tx_bytes"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			txBytes = append(txBytes, body.TxBytes)
+
+			if len(txBytes) == 1 {
+				return httpmock.NewStringResponse(http.StatusOK, `{"tx_response":{"height":"0","txhash":"`+testTxHash+`","codespace":"sdk","code":32,"raw_log":"account sequence mismatch, expected 6, got 5: incorrect account sequence"}}`), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"tx_response":{"height":"0","txhash":"`+testTxHash+`","code":0}}`), nil
+		})
+
+	c := NewClient(mockHTTPEndpoint, nil, nil)
+	c.SignMode = SignModeDirect
+
+	_, err = NewSequenceManager().SignAndBroadcast(context.Background(), c, w, tx, ModeSync)
+	assert.NoError(t, err)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 2, calls["GET "+mockHTTPEndpoint+"/cosmos/auth/v1beta1/accounts/"+w.Address])
+
+	// the transaction is signed again with the resynced sequence
+	want, err := w.SignDirect(tx, "cosmoshub-4", 11, 6)
+	require.NoError(t, err)
+
+	require.Len(t, txBytes, 2)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(want.Marshal()), txBytes[1])
+}
+
+func TestSequenceManager_SignAndBroadcast_sequenceAfterFailure(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		firstResponder    httpmock.Responder
+		wantAccountsCalls int
+		wantSameSequence  bool
+	}{
+		{
+			"rejected transaction keeps the local sequence",
+			httpmock.NewStringResponder(http.StatusOK, `{"height":"0","txhash":"`+testTxHash+`","codespace":"sdk","code":5,"raw_log":"insufficient funds"}`),
+			1,
+			true,
+		},
+		{
+			"transaction failed in a block consumes its sequence",
+			httpmock.NewStringResponder(http.StatusOK, `{"height":"1591","txhash":"`+testTxHash+`","codespace":"sdk","code":11,"raw_log":"out of gas"}`),
+			1,
+			false,
+		},
+		{
+			"unexpected status resyncs the sequence",
+			httpmock.NewStringResponder(http.StatusInternalServerError, `{"error":"timed out waiting for tx to be included in a block"}`),
+			2,
+			true,
+		},
+		{
+			"connection lost after sending resyncs the sequence",
+			func(req *http.Request) (*http.Response, error) {
+				return nil, &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+			},
+			2,
+			true,
+		},
+		{
+			"connection refused keeps the local sequence",
+			func(req *http.Request) (*http.Response, error) {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+			},
+			1,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/node_info",
+				httpmock.NewStringResponder(http.StatusOK, testNodeInfoJSON))
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/auth/accounts/"+w.Address,
+				httpmock.NewStringResponder(http.StatusOK, `{"height":"1590","result":{"type":"cosmos-sdk/Account","value":{"address":"`+w.Address+`","account_number":11,"sequence":5}}}`))
+
+			recorder := &testSignatureRecorder{}
+			first := true
+			httpmock.RegisterResponder("POST", mockHTTPEndpoint+"/txs", func(req *http.Request) (*http.Response, error) {
+				if first {
+					first = false
+					if _, err := recorder.respond(req); err != nil {
+						return nil, err
+					}
+
+					return tt.firstResponder(req)
+				}
+
+				return recorder.respond(req)
+			})
+
+			client := NewClient(mockHTTPEndpoint, nil, nil)
+			sm := NewSequenceManager()
+			tx := TransactionPayload{Fee: Fee{Amount: []Coin{}, Gas: "200000"}}
+
+			_, err := sm.SignAndBroadcast(context.Background(), client, w, tx, ModeSync)
+			assert.Error(t, err)
+
+			_, err = sm.SignAndBroadcast(context.Background(), client, w, tx, ModeSync)
+			assert.NoError(t, err)
+
+			calls := httpmock.GetCallCountInfo()
+			assert.Equal(t, tt.wantAccountsCalls, calls["GET "+mockHTTPEndpoint+"/auth/accounts/"+w.Address])
+
+			// signatures are deterministic, so the same sequence yields the same signature
+			require.Len(t, recorder.signatures, 2)
+			assert.Equal(t, tt.wantSameSequence, recorder.signatures[0] == recorder.signatures[1])
+		})
+	}
+}
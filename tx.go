@@ -0,0 +1,71 @@
+package sacco
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Tx queries the LCD for the transaction identified by hash.
+// If the LCD doesn't know about the transaction, an error wrapping ErrTxNotFound
+// is returned.
+func (c *Client) Tx(ctx context.Context, hash string) (TxResponse, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("/txs/%s", hash))
+	if err != nil {
+		return TxResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound {
+			return TxResponse{}, fmt.Errorf("%w: %s", ErrTxNotFound, hash)
+		}
+
+		return TxResponse{}, fmt.Errorf("error during get tx: %w", newHTTPError(resp))
+	}
+
+	var txr TxResponse
+
+	jdec := json.NewDecoder(resp.Body)
+	err = jdec.Decode(&txr)
+	if err != nil {
+		return TxResponse{}, fmt.Errorf("could not deserialize cosmos txresponse from lcd: %w", err)
+	}
+
+	return txr, nil
+}
+
+// WaitForTx polls the LCD until the transaction identified by hash is included in a block,
+// or ctx is done.
+// The final TxResponse is returned along with an error if the transaction has been included,
+// but its execution failed.
+func (c *Client) WaitForTx(ctx context.Context, hash string) (TxResponse, error) {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		txr, err := c.Tx(ctx, hash)
+		if err == nil {
+			return txr, txResponseError(txr)
+		}
+
+		if !errors.Is(err, ErrTxNotFound) {
+			return TxResponse{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return TxResponse{}, fmt.Errorf("transaction %s not included: %w", hash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
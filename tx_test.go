@@ -0,0 +1,149 @@
+package sacco
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTxHash = "D085138D913993919295FF4B0A9107F1F2CDE0D37A87CE0644E217CBF3B49656"
+
+func TestClient_Tx(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	tests := []struct {
+		name       string
+		jsonResp   string
+		statusResp int
+		want       TxResponse
+		notFound   bool
+		assertion  assert.ErrorAssertionFunc
+	}{
+		{
+			"transaction included in a block",
+			`{"height":"1590","txhash":"` + testTxHash + `","gas_wanted":"200000","gas_used":"52541","tx":{"type":"cosmos-sdk/StdTx","value":{"msg":[],"memo":""}},"timestamp":"2020-03-04T10:11:12Z"}`,
+			http.StatusOK,
+			TxResponse{
+				Height:    "1590",
+				TxHash:    testTxHash,
+				GasWanted: "200000",
+				GasUsed:   "52541",
+				RawTx:     []byte(`{"type":"cosmos-sdk/StdTx","value":{"msg":[],"memo":""}}`),
+				Timestamp: "2020-03-04T10:11:12Z",
+			},
+			false,
+			assert.NoError,
+		},
+		{
+			"transaction not found",
+			`{"error":"Tx (` + testTxHash + `) not found"}`,
+			http.StatusNotFound,
+			TxResponse{},
+			true,
+			assert.Error,
+		},
+		{
+			"internal LCD error mentioning something not found",
+			`{"error":"block meta not found for height 1590"}`,
+			http.StatusInternalServerError,
+			TxResponse{},
+			false,
+			assert.Error,
+		},
+		{
+			"generic LCD error",
+			`{"error":"encoding/hex: invalid byte"}`,
+			http.StatusInternalServerError,
+			TxResponse{},
+			false,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/txs/"+testTxHash,
+				httpmock.NewStringResponder(tt.statusResp, tt.jsonResp))
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			got, err := c.Tx(context.Background(), testTxHash)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.notFound, errors.Is(err, ErrTxNotFound))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_WaitForTx(t *testing.T) {
+	mockHTTPEndpoint := "http://127.0.0.1:3333"
+
+	tests := []struct {
+		name         string
+		pendingPolls int
+		code         string
+		timeout      time.Duration
+		wantHeight   string
+		assertion    assert.ErrorAssertionFunc
+	}{
+		{
+			"transaction included after some polls",
+			3,
+			"0",
+			time.Second,
+			"1590",
+			assert.NoError,
+		},
+		{
+			"transaction included, but failed",
+			0,
+			"11",
+			time.Second,
+			"1590",
+			assert.Error,
+		},
+		{
+			"transaction never included",
+			1000,
+			"0",
+			50 * time.Millisecond,
+			"",
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			polls := 0
+			httpmock.RegisterResponder("GET", mockHTTPEndpoint+"/txs/"+testTxHash,
+				func(req *http.Request) (*http.Response, error) {
+					polls++
+					if polls <= tt.pendingPolls {
+						return httpmock.NewStringResponse(http.StatusNotFound, `{"error":"Tx (`+testTxHash+`) not found"}`), nil
+					}
+
+					return httpmock.NewStringResponse(http.StatusOK, `{"height":"1590","txhash":"`+testTxHash+`","code":`+tt.code+`}`), nil
+				})
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			c := NewClient(mockHTTPEndpoint, nil, nil)
+			c.PollInterval = time.Millisecond
+
+			got, err := c.WaitForTx(ctx, testTxHash)
+
+			tt.assertion(t, err)
+			assert.Equal(t, tt.wantHeight, got.Height)
+		})
+	}
+}
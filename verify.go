@@ -0,0 +1,79 @@
+package sacco
+
+import (
+	"bytes"
+	"encoding/base64"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// secp256k1PubKeyType is the amino name of secp256k1 public keys.
+const secp256k1PubKeyType = "tendermint/PubKeySecp256k1"
+
+// Verify checks that tx has been signed with the given chainID, accountNumber and
+// sequenceNumber by the owner of address.
+// tx must hold exactly one secp256k1 or multisig signature.
+func Verify(tx SignedTransactionPayload, chainID, accountNumber, sequenceNumber, address string) error {
+	switch {
+	case len(tx.Signatures) == 0:
+		return ErrMissingSignature
+	case len(tx.Signatures) > 1:
+		return ErrMultipleSignatures
+	}
+
+	sig := tx.Signatures[0]
+
+	pubKey, err := sig.SigPubKey.tmPubKey()
+	if err != nil {
+		return err
+	}
+
+	_, addrBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return ErrMalformedAddress(address)
+	}
+
+	if !bytes.Equal(pubKey.Address().Bytes(), addrBytes) {
+		return ErrPubKeyMismatch(address)
+	}
+
+	unsignedTx := TransactionPayload(tx)
+	unsignedTx.Signatures = nil
+
+	return verifySignature(pubKey, sig.Signature, signBytes(unsignedTx, chainID, accountNumber, sequenceNumber))
+}
+
+// verifySignature checks that the base64-encoded signature has been made over
+// msgBytes with pubKey.
+func verifySignature(pubKey crypto.PubKey, signature string, msgBytes []byte) error {
+	rawSig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !pubKey.VerifyBytes(msgBytes, rawSig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// decodeSigPubKey returns the secp256k1 public key held by spk.
+func decodeSigPubKey(spk SigPubKey) (secp256k1.PubKeySecp256k1, error) {
+	var pubKey secp256k1.PubKeySecp256k1
+
+	if spk.Type != secp256k1PubKeyType {
+		return pubKey, ErrUnsupportedPubKeyType(spk.Type)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(spk.Value)
+	if err != nil || len(raw) != secp256k1.PubKeySecp256k1Size {
+		return pubKey, ErrMalformedPubKey
+	}
+
+	copy(pubKey[:], raw)
+
+	return pubKey, nil
+}
@@ -0,0 +1,86 @@
+package sacco
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	other, err := FromMnemonic("did:com:", testMnemonic, "m/44'/118'/0'/0/1")
+	assert.NoError(t, err)
+
+	tx := TransactionPayload{
+		Message: []json.RawMessage{
+			json.RawMessage(`{"type":"cosmos-sdk/MsgSend","value":{"from_address":"did:com:1sfjela2snk9rmmcfh773gm50476w0ur5pmwuak","to_address":"did:com:1kulfxlg33x9lmxa00gmmaq6j3nshtpnrr24tm9","amount":[{"denom":"ucommercio","amount":"10"}]}}`),
+		},
+		Fee: Fee{Amount: []Coin{}, Gas: "200000"},
+	}
+
+	signed, err := w.Sign(tx, "test-chain-jVvnJ6", "11", "0")
+	assert.NoError(t, err)
+
+	tampered := signed
+	tampered.Memo = "tampered"
+
+	unsigned := signed
+	unsigned.Signatures = nil
+
+	doubleSigned := signed
+	doubleSigned.Signatures = append(append([]Signature{}, signed.Signatures...), signed.Signatures...)
+
+	otherKey := signed
+	otherKey.Signatures = []Signature{{SigPubKey: SigPubKey{Type: "tendermint/PubKeyEd25519", Value: signed.Signatures[0].SigPubKey.Value}, Signature: signed.Signatures[0].Signature}}
+
+	tests := []struct {
+		name     string
+		tx       SignedTransactionPayload
+		sequence string
+		address  string
+		wantErr  error
+	}{
+		{"valid signature", signed, "0", w.Address, nil},
+		{"wrong sequence", signed, "1", w.Address, ErrInvalidSignature},
+		{"tampered transaction", tampered, "0", w.Address, ErrInvalidSignature},
+		{"other address", signed, "0", other.Address, ErrPubKeyMismatch(other.Address)},
+		{"invalid address", signed, "0", "did:com:invalid", ErrMalformedAddress("did:com:invalid")},
+		{"no signatures", unsigned, "0", w.Address, ErrMissingSignature},
+		{"too many signatures", doubleSigned, "0", w.Address, ErrMultipleSignatures},
+		{"unsupported public key type", otherKey, "0", w.Address, ErrUnsupportedPubKeyType("tendermint/PubKeyEd25519")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.tx, "test-chain-jVvnJ6", "11", tt.sequence, tt.address)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr.Error())
+
+			// local failures must not be mistaken for errors returned by the chain
+			assert.False(t, errors.Is(err, ErrNoSignatures) || errors.Is(err, ErrTooManySignatures) || errors.Is(err, ErrInvalidAddress))
+		})
+	}
+}
+
+func TestVerify_paddedSignatures(t *testing.T) {
+	w, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	// roughly 1 in 128 signatures has an R or S shorter than 32 bytes
+	for i := 0; i < 512; i++ {
+		tx := TransactionPayload{Fee: Fee{Amount: []Coin{}, Gas: "200000"}}
+		sequence := strconv.Itoa(i)
+
+		signed, err := w.Sign(tx, "test-chain-jVvnJ6", "11", sequence)
+		assert.NoError(t, err)
+		assert.NoError(t, Verify(signed, "test-chain-jVvnJ6", "11", sequence, w.Address))
+	}
+}
@@ -3,13 +3,16 @@ package sacco
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"strings"
 
 	"github.com/awnumar/memguard"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/hdkeychain"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/go-bip39"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
@@ -32,18 +35,137 @@ type Wallet struct {
 // FromMnemonic returns a new Wallet instance given a human-readable part,
 // mnemonic and path.
 func FromMnemonic(hrp, mnemonic, path string) (*Wallet, error) {
-	var w Wallet
-	k, a, err := deriveFromMnemonic(hrp, mnemonic, path)
+	return FromMnemonicWithPassphrase(hrp, mnemonic, "", path)
+}
+
+// FromMnemonicWithPassphrase returns a new Wallet instance given a human-readable part,
+// mnemonic, BIP-39 passphrase (the so-called "25th word") and path.
+// An empty passphrase yields the same Wallet as FromMnemonic.
+func FromMnemonicWithPassphrase(hrp, mnemonic, passphrase, path string) (*Wallet, error) {
+	k, a, err := deriveFromMnemonicWithPassphrase(hrp, mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWallet(hrp, k, a, path)
+}
+
+// FromPrivateKey returns a new Wallet instance given a human-readable part and a raw
+// 32 bytes secp256k1 private key.
+// Since a raw private key carries no chain code, the resulting Wallet PublicKey
+// can't be used to derive child keys.
+func FromPrivateKey(hrp string, privKey []byte) (*Wallet, error) {
+	key, err := extendedKeyFromPrivateKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletFromExtendedKey(hrp, key, "")
+}
+
+// FromHexPrivateKey returns a new Wallet instance given a human-readable part and a
+// hex-encoded raw 32 bytes secp256k1 private key.
+func FromHexPrivateKey(hrp, privKey string) (*Wallet, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(privKey, "0x"))
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return FromPrivateKey(hrp, raw)
+}
+
+// FromExtendedKey returns a new Wallet instance given a human-readable part and a
+// BIP-32 serialized extended private key, like the PrivateKey field of
+// ExportWithPrivateKey output.
+func FromExtendedKey(hrp, xprv string) (*Wallet, error) {
+	key, err := hdkeychain.NewKeyFromString(xprv)
+	if err != nil || !key.IsPrivate() {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return walletFromExtendedKey(hrp, key, "")
+}
+
+// Import returns a new Wallet instance given the JSON representation created
+// by ExportWithPrivateKey.
+func Import(data string) (*Wallet, error) {
+	var exported Wallet
+	if err := json.Unmarshal([]byte(data), &exported); err != nil {
+		return nil, err
+	}
+
+	if exported.PrivateKey == "" {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	w, err := FromExtendedKey(exported.HRP, exported.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
 
-	w.keyPair = k
+	w.Path = exported.Path
+
+	if exported.Address != "" && exported.Address != w.Address {
+		return nil, ErrImportMismatch("address")
+	}
+
+	if exported.PublicKey != "" && exported.PublicKey != w.PublicKey {
+		return nil, ErrImportMismatch("public_key")
+	}
+
+	return w, nil
+}
+
+// extendedKeyFromPrivateKey returns an extended private key made of privKey and
+// an empty chain code.
+func extendedKeyFromPrivateKey(privKey []byte) (*hdkeychain.ExtendedKey, error) {
+	k := new(big.Int).SetBytes(privKey)
+	if len(privKey) != btcec.PrivKeyBytesLen || k.Sign() == 0 || k.Cmp(btcec.S256().N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return hdkeychain.NewExtendedKey(
+		chaincfg.MainNetParams.HDPrivateKeyID[:],
+		privKey,
+		make([]byte, 32),
+		[]byte{0x00, 0x00, 0x00, 0x00},
+		0,
+		0,
+		true,
+	), nil
+}
+
+// walletFromExtendedKey returns a new Wallet instance given a human-readable part, an
+// extended key and the path it has been derived with, if known.
+func walletFromExtendedKey(hrp string, key *hdkeychain.ExtendedKey, path string) (*Wallet, error) {
+	epk, err := key.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := addressFromPublicKey(epk, hrp)
+	if err != nil {
+		return nil, ErrCouldNotBech32(err)
+	}
+
+	return newWallet(hrp, key, address, path)
+}
+
+// newWallet returns a new Wallet instance given a human-readable part, an extended
+// key, its address and the path it has been derived with.
+// If k is an extended public key, the Wallet is watch-only.
+func newWallet(hrp string, k *hdkeychain.ExtendedKey, address, path string) (*Wallet, error) {
+	var w Wallet
+
 	w.Path = path
-	w.Address = a
+	w.Address = address
 	w.HRP = hrp
 
-	pk, err := w.keyPair.Neuter()
+	if k.IsPrivate() {
+		w.keyPair = k
+	}
+
+	pk, err := k.Neuter()
 	if err != nil {
 		return nil, ErrCouldNotNeuter(err)
 	}
@@ -89,6 +211,10 @@ func (w Wallet) Export() (string, error) {
 // ExportWithPrivateKey creates a JSON representation of w.
 // ExportWithPrivateKey includes the private key in the JSON representation.
 func (w Wallet) ExportWithPrivateKey() (string, error) {
+	if w.IsWatchOnly() {
+		return "", ErrWatchOnly
+	}
+
 	w.PrivateKey = w.keyPair.String()
 
 	s := memguard.NewStream()
@@ -112,15 +238,10 @@ func (w Wallet) ExportWithPrivateKey() (string, error) {
 	return strings.TrimSpace(string(data.Bytes())), err
 }
 
-// GenerateMnemonic generates a new random mnemonic sequence.
+// GenerateMnemonic generates a new random 24-words mnemonic sequence, using the english
+// BIP-39 wordlist.
 func GenerateMnemonic() (string, error) {
-	sb, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
-	if err != nil {
-		return "", err
-	}
-	mnemonic, err := bip39.NewMnemonic(sb)
-
-	return mnemonic, err
+	return GenerateMnemonicWithOptions(24, LanguageEnglish)
 }
 
 // signBytes transforms a TransactionPayload with its chainID, accountNumber e sequenceNumber
@@ -142,43 +263,64 @@ func signBytes(tx TransactionPayload, chainID, accountNumber, sequenceNumber str
 // The resulting computation must be enclosed in a Transaction struct to be sent over the wire
 // to a Cosmos LCD.
 func (w Wallet) Sign(tx TransactionPayload, chainID, accountNumber, sequenceNumber string) (SignedTransactionPayload, error) {
-	signBytes := signBytes(tx, chainID, accountNumber, sequenceNumber)
+	if w.IsWatchOnly() {
+		return SignedTransactionPayload{}, ErrWatchOnly
+	}
 
-	pk, err := w.keyPair.ECPrivKey()
+	r, err := w.signRaw(signBytes(tx, chainID, accountNumber, sequenceNumber))
 	if err != nil {
 		return SignedTransactionPayload{}, err
 	}
 
+	sigPubKey, err := w.SigPubKey()
+	if err != nil {
+		return SignedTransactionPayload{}, err
+	}
+
+	tx.Signatures = []Signature{
+		{
+			Signature: base64.StdEncoding.EncodeToString(r),
+			SigPubKey: sigPubKey,
+		},
+	}
+
+	return SignedTransactionPayload(tx), nil
+}
+
+// signRaw signs the SHA-256 hash of signBytes with w's private key, and returns the
+// signature as the 64 bytes concatenation of R and S.
+func (w Wallet) signRaw(signBytes []byte) ([]byte, error) {
+	pk, err := w.keyPair.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
 	hashSb := sha256.Sum256(signBytes)
 	signatureRaw, err := pk.Sign(hashSb[:])
 	if err != nil {
-		return SignedTransactionPayload{}, err
+		return nil, err
 	}
 
-	signatureRaw.Serialize()
+	// R and S are encoded as 32 bytes big-endian integers each
 	rBytes := signatureRaw.R.Bytes()
 	sBytes := signatureRaw.S.Bytes()
 
-	pubKey, err := w.publicKey.ECPubKey()
-	if err != nil {
-		return SignedTransactionPayload{}, err
-	}
+	r := make([]byte, 64)
+	copy(r[32-len(rBytes):32], rBytes)
+	copy(r[64-len(sBytes):], sBytes)
 
-	r := []byte{}
-	r = append(r, rBytes...)
-	r = append(r, sBytes...)
-	signature := base64.StdEncoding.EncodeToString(r)
-	compressedPubKey := base64.StdEncoding.EncodeToString(pubKey.SerializeCompressed())
+	return r, nil
+}
 
-	tx.Signatures = []Signature{
-		{
-			Signature: signature,
-			SigPubKey: SigPubKey{
-				Type:  "tendermint/PubKeySecp256k1",
-				Value: compressedPubKey,
-			},
-		},
+// SigPubKey returns w's public key, as included in the signatures w produces.
+func (w Wallet) SigPubKey() (SigPubKey, error) {
+	pubKey, err := w.publicKey.ECPubKey()
+	if err != nil {
+		return SigPubKey{}, err
 	}
 
-	return SignedTransactionPayload(tx), nil
+	return SigPubKey{
+		Type:  secp256k1PubKeyType,
+		Value: base64.StdEncoding.EncodeToString(pubKey.SerializeCompressed()),
+	}, nil
 }
@@ -1,9 +1,12 @@
 package sacco
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,6 +32,16 @@ func TestFromMnemonic(t *testing.T) {
 			"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9",
 			assert.NoError,
 		},
+		{
+			"a well-formed italian mnemonic",
+			args{
+				hrp:      "did:com:",
+				mnemonic: "abaco abaco abaco abaco abaco abaco abaco abaco abaco abaco abaco abete",
+				path:     CosmosDerivationPath,
+			},
+			"did:com:1t89064ff08fuhn28ruktzps9kcf4t7d0vtn7k3",
+			assert.NoError,
+		},
 		{
 			"non-valid mnemonic",
 			args{
@@ -63,6 +76,77 @@ func TestFromMnemonic(t *testing.T) {
 	}
 }
 
+func TestFromMnemonicWithPassphrase(t *testing.T) {
+	type args struct {
+		hrp        string
+		mnemonic   string
+		passphrase string
+		path       string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantAddr  string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"empty passphrase, same address as FromMnemonic",
+			args{
+				hrp:        "cosmos",
+				mnemonic:   "final random flame cinnamon grunt hazard easily mutual resist pond solution define knife female tongue crime atom jaguar alert library best forum lesson rigid",
+				passphrase: "",
+				path:       CosmosDerivationPath,
+			},
+			"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9",
+			assert.NoError,
+		},
+		{
+			"non-empty passphrase",
+			args{
+				hrp:        "cosmos",
+				mnemonic:   "final random flame cinnamon grunt hazard easily mutual resist pond solution define knife female tongue crime atom jaguar alert library best forum lesson rigid",
+				passphrase: "TREZOR",
+				path:       CosmosDerivationPath,
+			},
+			"cosmos19rl8fvxhk2des9vhy0gk8pdm0d6ykmmmq25j45",
+			assert.NoError,
+		},
+		{
+			"another non-empty passphrase",
+			args{
+				hrp:        "cosmos",
+				mnemonic:   "final random flame cinnamon grunt hazard easily mutual resist pond solution define knife female tongue crime atom jaguar alert library best forum lesson rigid",
+				passphrase: "commercio",
+				path:       CosmosDerivationPath,
+			},
+			"cosmos16xzqt25dtwgheltpdw50ya5ajnsms4r3j5lkms",
+			assert.NoError,
+		},
+		{
+			"non-valid mnemonic",
+			args{
+				hrp:        "cosmos",
+				mnemonic:   "no",
+				passphrase: "TREZOR",
+				path:       CosmosDerivationPath,
+			},
+			"",
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromMnemonicWithPassphrase(tt.args.hrp, tt.args.mnemonic, tt.args.passphrase, tt.args.path)
+			tt.assertion(t, err)
+
+			// if Wallet was derived correctly, address must match what we expect
+			if got != nil {
+				assert.Equal(t, tt.wantAddr, got.Address)
+			}
+		})
+	}
+}
+
 func TestWallet_Export(t *testing.T) {
 	type args struct {
 		hrp      string
@@ -272,3 +356,138 @@ func TestWallet_Sign(t *testing.T) {
 		})
 	}
 }
+
+func TestImport(t *testing.T) {
+	w, err := FromMnemonic("cosmos", "final random flame cinnamon grunt hazard easily mutual resist pond solution define knife female tongue crime atom jaguar alert library best forum lesson rigid", CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	exported, err := w.ExportWithPrivateKey()
+	assert.NoError(t, err)
+
+	publicExport, err := w.Export()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		data      string
+		want      *Wallet
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			"ExportWithPrivateKey output",
+			exported,
+			w,
+			assert.NoError,
+		},
+		{
+			"Export output",
+			publicExport,
+			nil,
+			assert.Error,
+		},
+		{
+			"mismatching address",
+			strings.Replace(exported, w.Address, "cosmos1kulfxlg33x9lmxa00gmmaq6j3nshtpnrm8ecwk", 1),
+			nil,
+			assert.Error,
+		},
+		{
+			"malformed JSON",
+			"{",
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Import(tt.data)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+
+			if got != nil {
+				gotExport, err := got.ExportWithPrivateKey()
+				assert.NoError(t, err)
+				assert.Equal(t, tt.data, gotExport)
+			}
+		})
+	}
+}
+
+func TestFromExtendedKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		wantAddress string
+		assertion   assert.ErrorAssertionFunc
+	}{
+		{
+			"extended private key",
+			"xprvA2WoDzh5smCqgKZ9AUYXrMfGFT7aTDTfBtsGbkUcRAT13Geq4UTYbpFZm9BYmxMBtn4fK8LYndQ7HaneCLGwT35iW2VDmPKRdErwJHRkLgX",
+			"cosmos1huydeevpz37sd9snkgul6070mstupukw00xkw9",
+			assert.NoError,
+		},
+		{
+			"extended public key",
+			"xpub6FW9dWDyi8m8todcGW5YDVbzoUx4rgBWZ7nsQ8tDyVyyv4yyc1mo9ca3cRhDHfr2V3xhcHj5GDrBMoHCBZti5LRz1XrsVxSKWrPYbQFssKo",
+			"",
+			assert.Error,
+		},
+		{
+			"malformed key",
+			"xprv",
+			"",
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromExtendedKey("cosmos", tt.key)
+			tt.assertion(t, err)
+
+			if got != nil {
+				assert.Equal(t, tt.wantAddress, got.Address)
+				assert.Equal(t, "xpub6FW9dWDyi8m8todcGW5YDVbzoUx4rgBWZ7nsQ8tDyVyyv4yyc1mo9ca3cRhDHfr2V3xhcHj5GDrBMoHCBZti5LRz1XrsVxSKWrPYbQFssKo", got.PublicKey)
+			}
+		})
+	}
+}
+
+func TestFromPrivateKey(t *testing.T) {
+	w, err := FromMnemonic("cosmos", "final random flame cinnamon grunt hazard easily mutual resist pond solution define knife female tongue crime atom jaguar alert library best forum lesson rigid", CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	pk, err := w.keyPair.ECPrivKey()
+	assert.NoError(t, err)
+
+	got, err := FromPrivateKey("cosmos", pk.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, w.Address, got.Address)
+	assert.Equal(t, w.PublicKeyBech32, got.PublicKeyBech32)
+
+	got, err = FromHexPrivateKey("cosmos", hex.EncodeToString(pk.Serialize()))
+	assert.NoError(t, err)
+	assert.Equal(t, w.Address, got.Address)
+
+	// a key and its signatures are the same no matter how the Wallet has been created
+	tx := TransactionPayload{Fee: Fee{Amount: []Coin{}, Gas: "200000"}}
+	want, err := w.Sign(tx, "test-chain-jVvnJ6", "11", "0")
+	assert.NoError(t, err)
+
+	signed, err := got.Sign(tx, "test-chain-jVvnJ6", "11", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, want, signed)
+
+	for _, invalid := range [][]byte{
+		nil,
+		make([]byte, 32),
+		pk.Serialize()[:31],
+		append(pk.Serialize(), 0x00),
+		btcec.S256().N.Bytes(),
+	} {
+		_, err := FromPrivateKey("cosmos", invalid)
+		assert.Equal(t, ErrInvalidPrivateKey, err)
+	}
+
+	_, err = FromHexPrivateKey("cosmos", "not hex")
+	assert.Equal(t, ErrInvalidPrivateKey, err)
+}
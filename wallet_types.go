@@ -50,7 +50,11 @@ type Signature struct {
 }
 
 // SigPubKey represents the public key used to create a Signature.
+// Multisig public keys have no Value, and are described by Threshold and PubKeys
+// instead.
 type SigPubKey struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
+	Type      string      `json:"type"`
+	Value     string      `json:"value"`
+	Threshold uint        `json:"-"`
+	PubKeys   []SigPubKey `json:"-"`
 }
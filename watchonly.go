@@ -0,0 +1,144 @@
+package sacco
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// FromXPub returns a new watch-only Wallet instance given a human-readable part and a
+// BIP-32 serialized extended public key, like the PublicKey field of Export output.
+func FromXPub(hrp, xpub string) (*Wallet, error) {
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil || key.IsPrivate() {
+		return nil, ErrMalformedPubKey
+	}
+
+	return walletFromExtendedKey(hrp, key, "")
+}
+
+// FromBech32PubKey returns a new watch-only Wallet instance given a human-readable part
+// and a bech32 account public key, like the PublicKeyBech32 field of Export output.
+// pubKey human-readable part must be hrp followed by "pub".
+func FromBech32PubKey(hrp, pubKey string) (*Wallet, error) {
+	pkHRP, bz, err := bech32.DecodeAndConvert(pubKey)
+	if err != nil || pkHRP != hrp+"pub" {
+		return nil, ErrMalformedPubKey
+	}
+
+	var cdc = amino.NewCodec()
+
+	cdc.RegisterInterface((*crypto.PubKey)(nil), nil)
+	cdc.RegisterConcrete(secp256k1.PubKeySecp256k1{},
+		secp256k1PubKeyType, nil)
+
+	var pubkTm crypto.PubKey
+	if err := cdc.UnmarshalBinaryBare(bz, &pubkTm); err != nil {
+		return nil, ErrMalformedPubKey
+	}
+
+	secpPubKey, ok := pubkTm.(secp256k1.PubKeySecp256k1)
+	if !ok {
+		return nil, ErrMalformedPubKey
+	}
+
+	return fromPublicKey(hrp, secpPubKey[:])
+}
+
+// FromHexPubKey returns a new watch-only Wallet instance given a human-readable part and
+// a hex-encoded secp256k1 public key, either compressed or uncompressed.
+func FromHexPubKey(hrp, pubKey string) (*Wallet, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(pubKey, "0x"))
+	if err != nil {
+		return nil, ErrMalformedPubKey
+	}
+
+	return fromPublicKey(hrp, raw)
+}
+
+// fromPublicKey returns a new watch-only Wallet instance given a human-readable part
+// and a raw secp256k1 public key.
+// Since a raw public key carries no chain code, the resulting Wallet can't derive
+// child keys.
+func fromPublicKey(hrp string, pubKey []byte) (*Wallet, error) {
+	pk, err := btcec.ParsePubKey(pubKey, btcec.S256())
+	if err != nil {
+		return nil, ErrMalformedPubKey
+	}
+
+	key := hdkeychain.NewExtendedKey(
+		chaincfg.MainNetParams.HDPublicKeyID[:],
+		pk.SerializeCompressed(),
+		make([]byte, 32),
+		[]byte{0x00, 0x00, 0x00, 0x00},
+		0,
+		0,
+		false,
+	)
+
+	return walletFromExtendedKey(hrp, key, "")
+}
+
+// IsWatchOnly returns true whether w holds only a public key, and thus can't sign.
+func (w Wallet) IsWatchOnly() bool {
+	return w.keyPair == nil
+}
+
+// Child returns the Wallet holding the non-hardened child key of w at index.
+// The child of a watch-only Wallet is watch-only too.
+// Wallets built from a raw public or private key have no chain code, hence their
+// children can't be derived and ErrNoChainCode is returned.
+func (w Wallet) Child(index uint32) (*Wallet, error) {
+	if index >= hdkeychain.HardenedKeyStart {
+		return nil, ErrKeyGeneration(hdkeychain.ErrDeriveHardFromPublic)
+	}
+
+	parent := w.publicKey
+	if !w.IsWatchOnly() {
+		parent = w.keyPair
+	}
+
+	if !hasChainCode(parent) {
+		return nil, ErrNoChainCode
+	}
+
+	child, err := parent.Child(index)
+	if err != nil {
+		return nil, ErrKeyGeneration(err)
+	}
+
+	path := ""
+	if w.Path != "" {
+		path = fmt.Sprintf("%s/%d", w.Path, index)
+	}
+
+	return walletFromExtendedKey(w.HRP, child, path)
+}
+
+// hasChainCode returns true whether the chain code of k isn't made only of zeroes,
+// as the one of extended keys built from raw public or private keys.
+func hasChainCode(k *hdkeychain.ExtendedKey) bool {
+	// version (4) || depth (1) || parent fingerprint (4) || child number (4) ||
+	// chain code (32) || key (33) || checksum (4)
+	payload := base58.Decode(k.String())
+	if len(payload) < 45 {
+		return false
+	}
+
+	for _, b := range payload[13:45] {
+		if b != 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package sacco
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromXPub(t *testing.T) {
+	// the external chain of the first account
+	account, err := FromMnemonic("did:com:", testMnemonic, "m/44'/118'/0'/0")
+	assert.NoError(t, err)
+
+	w, err := FromXPub("did:com:", account.PublicKey)
+	assert.NoError(t, err)
+	assert.True(t, w.IsWatchOnly())
+	assert.False(t, account.IsWatchOnly())
+	assert.Equal(t, account.Address, w.Address)
+	assert.Equal(t, account.PublicKeyBech32, w.PublicKeyBech32)
+
+	for i := uint32(0); i < 3; i++ {
+		want, err := FromMnemonic("did:com:", testMnemonic, fmt.Sprintf("m/44'/118'/0'/0/%d", i))
+		assert.NoError(t, err)
+
+		child, err := w.Child(i)
+		assert.NoError(t, err)
+		assert.True(t, child.IsWatchOnly())
+		assert.Equal(t, want.Address, child.Address)
+		assert.Equal(t, want.PublicKey, child.PublicKey)
+
+		// children of full Wallets can sign
+		fullChild, err := account.Child(i)
+		assert.NoError(t, err)
+		assert.Equal(t, want, fullChild)
+	}
+
+	_, err = w.Child(0x80000000)
+	assert.Error(t, err)
+
+	_, err = FromXPub("did:com:", "xprvA2WoDzh5smCqgKZ9AUYXrMfGFT7aTDTfBtsGbkUcRAT13Geq4UTYbpFZm9BYmxMBtn4fK8LYndQ7HaneCLGwT35iW2VDmPKRdErwJHRkLgX")
+	assert.Equal(t, ErrMalformedPubKey, err)
+}
+
+func TestFromBech32PubKey(t *testing.T) {
+	full, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	w, err := FromBech32PubKey("did:com:", full.PublicKeyBech32)
+	assert.NoError(t, err)
+	assert.True(t, w.IsWatchOnly())
+	assert.Equal(t, full.Address, w.Address)
+	assert.Equal(t, full.PublicKeyBech32, w.PublicKeyBech32)
+
+	_, err = FromBech32PubKey("cosmos", full.PublicKeyBech32)
+	assert.Equal(t, ErrMalformedPubKey, err)
+
+	_, err = FromBech32PubKey("did:com:", full.Address)
+	assert.Equal(t, ErrMalformedPubKey, err)
+}
+
+func TestFromHexPubKey(t *testing.T) {
+	full, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	pk, err := full.publicKey.ECPubKey()
+	assert.NoError(t, err)
+
+	for _, pubKey := range []string{
+		hex.EncodeToString(pk.SerializeCompressed()),
+		hex.EncodeToString(pk.SerializeUncompressed()),
+	} {
+		w, err := FromHexPubKey("did:com:", pubKey)
+		assert.NoError(t, err)
+		assert.True(t, w.IsWatchOnly())
+		assert.Equal(t, full.Address, w.Address)
+	}
+
+	_, err = FromHexPubKey("did:com:", "02deadbeef")
+	assert.Equal(t, ErrMalformedPubKey, err)
+}
+
+func TestWallet_watchOnly(t *testing.T) {
+	full, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	w, err := FromBech32PubKey("did:com:", full.PublicKeyBech32)
+	assert.NoError(t, err)
+
+	_, err = w.Sign(TransactionPayload{}, "test-chain-jVvnJ6", "11", "0")
+	assert.Equal(t, ErrWatchOnly, err)
+
+	_, err = w.PartialSign(TransactionPayload{}, "test-chain-jVvnJ6", "11", "0")
+	assert.Equal(t, ErrWatchOnly, err)
+
+	_, err = w.ExportWithPrivateKey()
+	assert.Equal(t, ErrWatchOnly, err)
+
+	_, err = w.EncryptArmor("passphrase")
+	assert.Equal(t, ErrWatchOnly, err)
+
+	_, err = w.Export()
+	assert.NoError(t, err)
+
+	sigPubKey, err := w.SigPubKey()
+	assert.NoError(t, err)
+
+	want, err := full.SigPubKey()
+	assert.NoError(t, err)
+	assert.Equal(t, want, sigPubKey)
+}
+
+func TestWallet_Child_noChainCode(t *testing.T) {
+	full, err := FromMnemonic("did:com:", testMnemonic, CosmosDerivationPath)
+	assert.NoError(t, err)
+
+	pk, err := full.publicKey.ECPubKey()
+	assert.NoError(t, err)
+
+	fromHex, err := FromHexPubKey("did:com:", hex.EncodeToString(pk.SerializeCompressed()))
+	assert.NoError(t, err)
+
+	fromBech32, err := FromBech32PubKey("did:com:", full.PublicKeyBech32)
+	assert.NoError(t, err)
+
+	sk, err := full.keyPair.ECPrivKey()
+	assert.NoError(t, err)
+
+	fromPrivKey, err := FromPrivateKey("did:com:", sk.Serialize())
+	assert.NoError(t, err)
+
+	exported, err := fromPrivKey.Export()
+	assert.NoError(t, err)
+
+	var exportedWallet Wallet
+	assert.NoError(t, json.Unmarshal([]byte(exported), &exportedWallet))
+
+	fromExportedXPub, err := FromXPub("did:com:", exportedWallet.PublicKey)
+	assert.NoError(t, err)
+
+	for _, w := range []*Wallet{fromHex, fromBech32, fromPrivKey, fromExportedXPub} {
+		_, err := w.Child(0)
+		assert.Equal(t, ErrNoChainCode, err)
+	}
+
+	_, err = full.Child(0)
+	assert.NoError(t, err)
+}